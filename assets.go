@@ -0,0 +1,228 @@
+package vite
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	nethttp "net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/support/path"
+)
+
+// etagKey identifies a version of a file in the assets file system, so a
+// file system that changes, such as os.DirFS, does not serve stale ETags.
+type etagKey struct {
+	name    string
+	size    int64
+	modTime time.Time
+}
+
+// readBuildFile reads a file under vite.assets_path, such as the manifest,
+// from the assets file system when one is set and from disk otherwise. It
+// also returns the path the file was read from, for error messages.
+func (v *Vite) readBuildFile(file string) ([]byte, string, error) {
+	if v.config.FS == nil {
+		file = path.Base(file)
+		data, err := os.ReadFile(file)
+
+		return data, file, err
+	}
+
+	name := v.fsName(file)
+	data, err := fs.ReadFile(v.config.FS, name)
+
+	return data, name, err
+}
+
+// statBuildFile stats a built file, named relative to vite.assets_path as in
+// the manifest, in the assets file system when one is set and on disk
+// otherwise.
+func (v *Vite) statBuildFile(name string) (fs.FileInfo, error) {
+	if v.config.FS != nil {
+		return fs.Stat(v.config.FS, name)
+	}
+
+	return os.Stat(filepath.Join(path.Base(v.config.AssetsPath), name))
+}
+
+// fsName maps a configured path to its name in the assets file system, whose
+// root is vite.assets_path.
+func (v *Vite) fsName(file string) string {
+	return relativeToAssets(v.config.AssetsPath, file)
+}
+
+const immutableCacheControl = "public, max-age=31536000, immutable"
+
+// assetServer serves the build output from a file system. Files referenced
+// by the manifest have content-hashed names and are cached for a year;
+// anything else, such as files copied from Vite's public directory, gets
+// vite.cache_max_age. The manifests and the hot file are internal to the
+// package and hidden unless vite.hide_manifest is disabled.
+type assetServer struct {
+	vite     *Vite
+	fsys     fs.FS
+	internal map[string]bool
+}
+
+func (v *Vite) newAssetServer(fsys fs.FS) *assetServer {
+	internal := make(map[string]bool)
+	for _, file := range []string{v.config.ManifestPath, v.config.SSR.ManifestPath, v.config.HotFile} {
+		internal[v.fsName(file)] = true
+	}
+
+	return &assetServer{vite: v, fsys: fsys, internal: internal}
+}
+
+func (s *assetServer) handle(ctx http.Context) http.Response {
+	s.serve(ctx.Response().Writer(), ctx.Request().Origin(), ctx.Request().Route("path"))
+
+	return nil
+}
+
+// serve writes a file of the build output. http.ServeContent sets the content
+// type from the extension and answers conditional and range requests; the
+// ETag, a hash of the content, validates files without a modification time,
+// such as embedded ones. A precompressed sibling, or with vite.compress a
+// gzipped copy, is sent instead when the client accepts it.
+func (s *assetServer) serve(w nethttp.ResponseWriter, r *nethttp.Request, name string) {
+	if r.Method != nethttp.MethodGet && r.Method != nethttp.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		nethttp.Error(w, nethttp.StatusText(nethttp.StatusMethodNotAllowed), nethttp.StatusMethodNotAllowed)
+		return
+	}
+
+	config := s.vite.config
+	name = strings.TrimPrefix(name, "/")
+	if !fs.ValidPath(name) || name == "." || (config.HideManifest && s.internal[name]) {
+		nethttp.NotFound(w, r)
+		return
+	}
+
+	file, info, err := s.open(name)
+	if err != nil {
+		nethttp.NotFound(w, r)
+		return
+	}
+	defer func() {
+		file.Close()
+	}()
+
+	served, encoding := name, ""
+	encodings := acceptedEncodings(r.Header.Get("Accept-Encoding"))
+	if config.Precompressed {
+		for _, variant := range precompressedVariants {
+			if !encodings[variant.encoding] {
+				continue
+			}
+			if variantFile, variantInfo, err := s.open(name + variant.ext); err == nil {
+				file.Close()
+				file, info = variantFile, variantInfo
+				served, encoding = name+variant.ext, variant.encoding
+				break
+			}
+		}
+	}
+
+	content, ok := file.(io.ReadSeeker)
+	if !ok {
+		data, err := io.ReadAll(file)
+		if err != nil {
+			nethttp.Error(w, err.Error(), nethttp.StatusInternalServerError)
+			return
+		}
+		content = bytes.NewReader(data)
+	}
+
+	key := etagKey{name: served, size: info.Size(), modTime: info.ModTime()}
+	etag, err := s.vite.assetETag(key, content)
+	if err != nil {
+		nethttp.Error(w, err.Error(), nethttp.StatusInternalServerError)
+		return
+	}
+
+	if encoding == "" && config.Compress && encodings["gzip"] && compressible(name) {
+		var gzipped bool
+		content, etag, gzipped, err = s.vite.gzipped(key, etag, content)
+		if err != nil {
+			nethttp.Error(w, err.Error(), nethttp.StatusInternalServerError)
+			return
+		}
+		if gzipped {
+			encoding = "gzip"
+		}
+	}
+
+	if config.Precompressed || config.Compress {
+		w.Header().Add("Vary", "Accept-Encoding")
+	}
+	if encoding != "" {
+		w.Header().Set("Content-Encoding", encoding)
+	}
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", s.cacheControl(name))
+	nethttp.ServeContent(w, r, name, info.ModTime(), content)
+}
+
+// open opens a regular file of the file system.
+func (s *assetServer) open(name string) (fs.File, fs.FileInfo, error) {
+	file, err := s.fsys.Open(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info, err := file.Stat()
+	if err == nil && info.IsDir() {
+		err = fs.ErrNotExist
+	}
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+
+	return file, info, nil
+}
+
+func (s *assetServer) cacheControl(name string) string {
+	if s.internal[name] {
+		return "no-cache"
+	}
+
+	if build, err := s.vite.loadManifest(); err == nil && build.files[name] {
+		return immutableCacheControl
+	}
+
+	if maxAge := int64(s.vite.config.CacheMaxAge / time.Second); maxAge > 0 {
+		return fmt.Sprintf("public, max-age=%d", maxAge)
+	}
+
+	return "no-cache"
+}
+
+// assetETag returns the cached ETag of a file, hashing content and rewinding
+// it on first use.
+func (v *Vite) assetETag(key etagKey, content io.ReadSeeker) (string, error) {
+	if etag, ok := v.etags.Load(key); ok {
+		return etag.(string), nil
+	}
+
+	h := sha256.New()
+	if _, err := io.Copy(h, content); err != nil {
+		return "", err
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	etag := `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+	v.etags.Store(key, etag)
+
+	return etag, nil
+}
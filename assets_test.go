@@ -0,0 +1,118 @@
+package vite
+
+import (
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFSTestConfig() Config {
+	config := DefaultConfig()
+	config.FS = fstest.MapFS{
+		".vite/manifest.json": {Data: []byte(`{
+			"resources/js/main.ts": {"file": "assets/main.js", "isEntry": true, "css": ["assets/main.css"]}
+		}`)},
+		"assets/main.js":  {Data: []byte(`console.log("main")`)},
+		"assets/main.css": {Data: []byte(`body{color:red}`)},
+	}
+
+	return config
+}
+
+func TestReadManifest_FS(t *testing.T) {
+	config := newFSTestConfig()
+	config.Integrity = "sha256"
+
+	build, err := NewViteWithConfig(config).readManifest()
+	require.NoError(t, err)
+
+	assert.Equal(t, "assets/main.js", build.manifest["resources/js/main.ts"].File)
+	assert.Equal(t, "sha256-FcQqt3aNlV7AZnGV4zkQRVeCeJOxbMPnQSx258L803E=", build.integrities["assets/main.css"])
+}
+
+func TestReadManifest_FSMissing(t *testing.T) {
+	config := newFSTestConfig()
+	config.AssetsPath = "public/build/"
+	config.ManifestPath = "public/build/manifest.json"
+
+	_, err := NewViteWithConfig(config).readManifest()
+	assert.ErrorContains(t, err, `reading manifest file "manifest.json"`)
+}
+
+func newTestAssetServer(hide bool, maxAge time.Duration, compress bool) *assetServer {
+	config := newFSTestConfig()
+	config.FS.(fstest.MapFS)["robots.txt"] = &fstest.MapFile{Data: []byte("User-agent: *")}
+	config.HideManifest = hide
+	config.CacheMaxAge = maxAge
+	config.Compress = compress
+
+	return NewViteWithConfig(config).newAssetServer(config.FS)
+}
+
+func serveTestAsset(server *assetServer, method, name string, header map[string]string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, "/static/"+name, nil)
+	for key, value := range header {
+		r.Header.Set(key, value)
+	}
+	w := httptest.NewRecorder()
+	server.serve(w, r, "/"+name)
+
+	return w
+}
+
+func TestAssetServer(t *testing.T) {
+	server := newTestAssetServer(true, 0, false)
+
+	w := serveTestAsset(server, nethttp.MethodGet, "assets/main.css", nil)
+	assert.Equal(t, nethttp.StatusOK, w.Code)
+	assert.Equal(t, "text/css; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "public, max-age=31536000, immutable", w.Header().Get("Cache-Control"))
+	assert.Equal(t, "body{color:red}", w.Body.String())
+	etag := w.Header().Get("ETag")
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, etag)
+
+	w = serveTestAsset(server, nethttp.MethodGet, "assets/main.css", map[string]string{"If-None-Match": etag})
+	assert.Equal(t, nethttp.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	w = serveTestAsset(server, nethttp.MethodGet, "assets/main.js", map[string]string{"Range": "bytes=0-6"})
+	assert.Equal(t, nethttp.StatusPartialContent, w.Code)
+	assert.Equal(t, "text/javascript; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "bytes 0-6/19", w.Header().Get("Content-Range"))
+	assert.Equal(t, "console", w.Body.String())
+
+	w = serveTestAsset(server, nethttp.MethodHead, "assets/main.js", nil)
+	assert.Equal(t, nethttp.StatusOK, w.Code)
+	assert.Equal(t, "19", w.Header().Get("Content-Length"))
+
+	w = serveTestAsset(server, nethttp.MethodGet, "robots.txt", nil)
+	assert.Equal(t, nethttp.StatusOK, w.Code)
+	assert.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+
+	assert.Equal(t, nethttp.StatusNotFound, serveTestAsset(server, nethttp.MethodGet, ".vite/manifest.json", nil).Code)
+	assert.Equal(t, nethttp.StatusNotFound, serveTestAsset(server, nethttp.MethodGet, "assets/missing.js", nil).Code)
+	assert.Equal(t, nethttp.StatusNotFound, serveTestAsset(server, nethttp.MethodGet, "assets", nil).Code)
+	assert.Equal(t, nethttp.StatusNotFound, serveTestAsset(server, nethttp.MethodGet, "../go.mod", nil).Code)
+
+	w = serveTestAsset(server, nethttp.MethodPost, "assets/main.js", nil)
+	assert.Equal(t, nethttp.StatusMethodNotAllowed, w.Code)
+	assert.Equal(t, "GET, HEAD", w.Header().Get("Allow"))
+}
+
+func TestAssetServer_PublicManifestAndMaxAge(t *testing.T) {
+	server := newTestAssetServer(false, time.Hour, false)
+
+	w := serveTestAsset(server, nethttp.MethodGet, ".vite/manifest.json", nil)
+	assert.Equal(t, nethttp.StatusOK, w.Code)
+	assert.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+
+	w = serveTestAsset(server, nethttp.MethodGet, "robots.txt", nil)
+	assert.Equal(t, nethttp.StatusOK, w.Code)
+	assert.Equal(t, "public, max-age=3600", w.Header().Get("Cache-Control"))
+	assert.Equal(t, "public, max-age=31536000, immutable", serveTestAsset(server, nethttp.MethodGet, "assets/main.js", nil).Header().Get("Cache-Control"))
+}
@@ -0,0 +1,116 @@
+package vite
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/contracts/foundation"
+	"github.com/goravel/framework/support/path"
+)
+
+// BuildCommand runs the build script of the frontend and checks the manifest
+// it produces against vite.entry_points, exiting with a non-zero code when
+// either fails.
+type BuildCommand struct {
+	app foundation.Application
+}
+
+func NewBuildCommand(app foundation.Application) *BuildCommand {
+	return &BuildCommand{app: app}
+}
+
+// Signature The name and signature of the console command.
+func (receiver *BuildCommand) Signature() string {
+	return "vite:build"
+}
+
+// Description The console command description.
+func (receiver *BuildCommand) Description() string {
+	return "Build the frontend and validate the Vite manifest"
+}
+
+// Extend The console command extend.
+func (receiver *BuildCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "vite",
+	}
+}
+
+// Handle Execute the console command.
+func (receiver *BuildCommand) Handle(ctx console.Context) error {
+	instance, err := receiver.app.Make(Binding)
+	if err != nil {
+		return &exitError{message: err.Error(), code: 1}
+	}
+
+	dir := receiver.app.BasePath()
+	manager := packageManager(dir)
+	output := newPrefixWriter(os.Stdout, "[vite] ")
+
+	cmd := exec.Command(manager, "run", "build")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "FORCE_COLOR=1")
+	cmd.Stdout = output
+	cmd.Stderr = output
+
+	ctx.Info(fmt.Sprintf("Running %s run build", manager))
+	err = cmd.Run()
+	output.Flush()
+	if err != nil {
+		code := 1
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+			code = exitErr.ExitCode()
+		}
+		return &exitError{message: fmt.Sprintf("%s run build failed: %v", manager, err), code: code}
+	}
+
+	problems := instance.(*Vite).buildProblems()
+	if len(problems) > 0 {
+		ctx.Error("The build does not match the Vite configuration:")
+		for _, problem := range problems {
+			ctx.Line("  " + problem)
+		}
+		return &exitError{message: fmt.Sprintf("vite:build found %d problem(s)", len(problems)), code: 1}
+	}
+
+	ctx.Success("Build complete and manifest validated.")
+
+	return nil
+}
+
+// buildProblems reads the manifest from disk and reports every configured
+// entry point it lacks and every file it references that is missing from the
+// build output.
+func (v *Vite) buildProblems() []string {
+	entries := v.allEntryPoints()
+	if len(entries) == 0 {
+		return []string{"No entry points configured in vite.entry_points"}
+	}
+
+	build, err := v.readManifest()
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	var problems []string
+	for _, entry := range entries {
+		if _, ok := build.manifest[entry]; !ok {
+			problems = append(problems, "Entry point "+entry+" is not in the manifest; add it to build.rollupOptions.input in vite.config.ts")
+		}
+	}
+
+	assetsPath := path.Base(v.config.AssetsPath)
+	if v.config.FS != nil {
+		assetsPath = "vite.fs"
+	}
+	for _, file := range v.missingFiles(build) {
+		problems = append(problems, fmt.Sprintf("%s is missing from %s", file, assetsPath))
+	}
+
+	return problems
+}
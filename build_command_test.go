@@ -0,0 +1,88 @@
+package vite
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	mocksconsole "github.com/goravel/framework/mocks/console"
+	mocksfoundation "github.com/goravel/framework/mocks/foundation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateBuild(t *testing.T) {
+	assetsPath := t.TempDir()
+	manifestPath := filepath.Join(assetsPath, "manifest.json")
+	require.NoError(t, os.WriteFile(manifestPath, []byte(manifestCommandTestManifest), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(assetsPath, "assets"), 0755))
+	for _, file := range []string{"main.js", "main.css", "vendor.js", "Dashboard.js"} {
+		require.NoError(t, os.WriteFile(filepath.Join(assetsPath, "assets", file), []byte(""), 0644))
+	}
+
+	config := testConfig(assetsPath)
+	config.EntryPoints = []string{"resources/js/main.ts", "resources/js/admin.ts"}
+
+	assert.Equal(t, []string{
+		"Entry point resources/js/admin.ts is not in the manifest; add it to build.rollupOptions.input in vite.config.ts",
+		"assets/unused.js is missing from " + assetsPath,
+	}, NewViteWithConfig(config).buildProblems())
+}
+
+func TestValidateBuild_NoEntryPoints(t *testing.T) {
+	config := testConfig(t.TempDir())
+	config.EntryPoints = nil
+
+	assert.Equal(t, []string{"No entry points configured in vite.entry_points"}, NewViteWithConfig(config).buildProblems())
+}
+
+func TestBuildCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake package manager is a shell script")
+	}
+
+	dir := t.TempDir()
+	bin := t.TempDir()
+	assetsPath := filepath.Join(dir, "public", "build")
+	manifestPath := filepath.Join(assetsPath, ".vite", "manifest.json")
+
+	// The fake npm writes a manifest with a single entry and its file.
+	require.NoError(t, os.WriteFile(filepath.Join(bin, "npm"), []byte(`#!/bin/sh
+[ "$1 $2" = "run build" ] || exit 3
+mkdir -p public/build/.vite public/build/assets
+echo '{"resources/js/main.ts": {"file": "assets/main.js", "isEntry": true}}' > public/build/.vite/manifest.json
+touch public/build/assets/main.js
+echo built
+`), 0755))
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	newCommand := func(entryPoints ...string) *BuildCommand {
+		config := testConfig(assetsPath)
+		config.ManifestPath = manifestPath
+		config.EntryPoints = entryPoints
+
+		mockApp := mocksfoundation.NewApplication(t)
+		mockApp.EXPECT().Make(Binding).Return(NewViteWithConfig(config), nil).Once()
+		mockApp.EXPECT().BasePath().Return(dir).Once()
+
+		return NewBuildCommand(mockApp)
+	}
+
+	mockCtx := mocksconsole.NewContext(t)
+	mockCtx.EXPECT().Info("Running npm run build").Once()
+	mockCtx.EXPECT().Success(mock.Anything).Once()
+	require.NoError(t, newCommand("resources/js/main.ts").Handle(mockCtx))
+
+	mockCtx = mocksconsole.NewContext(t)
+	mockCtx.EXPECT().Info("Running npm run build").Once()
+	mockCtx.EXPECT().Error("The build does not match the Vite configuration:").Once()
+	mockCtx.EXPECT().Line("  Entry point resources/js/admin.ts is not in the manifest; add it to build.rollupOptions.input in vite.config.ts").Once()
+
+	err := newCommand("resources/js/main.ts", "resources/js/admin.ts").Handle(mockCtx)
+	var exitErr *exitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 1, exitErr.ExitCode())
+	assert.Equal(t, "vite:build found 1 problem(s)", exitErr.Error())
+}
@@ -0,0 +1,114 @@
+package vite
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"mime"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// precompressedVariants are the siblings a Vite compression plugin writes next
+// to each file, in order of preference.
+var precompressedVariants = []struct {
+	encoding string
+	ext      string
+}{
+	{"br", ".br"},
+	{"gzip", ".gz"},
+}
+
+// compressedAsset is a file gzipped on first request. data is nil when
+// compressing did not make it smaller.
+type compressedAsset struct {
+	data []byte
+	etag string
+}
+
+// acceptedEncodings parses an Accept-Encoding header into the content codings
+// the client accepts, honouring q=0 and the "*" wildcard.
+func acceptedEncodings(header string) map[string]bool {
+	accepted := make(map[string]bool)
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding == "" {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			key, value, ok := strings.Cut(param, "=")
+			if ok && strings.EqualFold(strings.TrimSpace(key), "q") {
+				if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		accepted[coding] = q > 0
+	}
+
+	if wildcard, ok := accepted["*"]; ok {
+		for _, variant := range precompressedVariants {
+			if _, ok := accepted[variant.encoding]; !ok {
+				accepted[variant.encoding] = wildcard
+			}
+		}
+	}
+
+	return accepted
+}
+
+// compressible reports whether a file is text worth compressing, as opposed
+// to images, fonts and archives that are compressed already.
+func compressible(name string) bool {
+	mediaType, _, _ := mime.ParseMediaType(mime.TypeByExtension(path.Ext(name)))
+	if strings.HasPrefix(mediaType, "text/") {
+		return true
+	}
+
+	switch mediaType {
+	case "application/javascript", "application/json", "application/manifest+json", "application/wasm", "application/xml", "image/svg+xml":
+		return true
+	}
+
+	return false
+}
+
+// gzipped returns the gzip encoding of a file and its ETag, compressing
+// content on first use and keeping the result in memory. ok is false when
+// compressing does not make the file smaller.
+func (v *Vite) gzipped(key etagKey, etag string, content io.ReadSeeker) (io.ReadSeeker, string, bool, error) {
+	cached, found := v.compressed.Load(key)
+	if !found {
+		var buf bytes.Buffer
+		writer, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+		if err != nil {
+			return nil, "", false, err
+		}
+		if _, err := io.Copy(writer, content); err != nil {
+			return nil, "", false, err
+		}
+		if err := writer.Close(); err != nil {
+			return nil, "", false, err
+		}
+		if _, err := content.Seek(0, io.SeekStart); err != nil {
+			return nil, "", false, err
+		}
+
+		asset := compressedAsset{etag: strings.TrimSuffix(etag, `"`) + `-gzip"`}
+		if int64(buf.Len()) < key.size {
+			asset.data = buf.Bytes()
+		}
+		cached, _ = v.compressed.LoadOrStore(key, asset)
+	}
+
+	asset := cached.(compressedAsset)
+	if asset.data == nil {
+		return content, etag, false, nil
+	}
+
+	return bytes.NewReader(asset.data), asset.etag, true, nil
+}
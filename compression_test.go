@@ -0,0 +1,130 @@
+package vite
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	nethttp "net/http"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcceptedEncodings(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected map[string]bool
+	}{
+		{"", map[string]bool{}},
+		{"gzip, deflate, br, zstd", map[string]bool{"gzip": true, "deflate": true, "br": true, "zstd": true}},
+		{"GZIP;q=0.5, br;q=0", map[string]bool{"gzip": true, "br": false}},
+		{"*", map[string]bool{"*": true, "br": true, "gzip": true}},
+		{"br;q=0, *;q=0.1", map[string]bool{"*": true, "br": false, "gzip": true}},
+		{"identity; q=1, *;q=0", map[string]bool{"identity": true, "*": false, "br": false, "gzip": false}},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, acceptedEncodings(test.header), test.header)
+	}
+}
+
+func TestCompressible(t *testing.T) {
+	for _, name := range []string{"assets/main.js", "assets/main.css", "index.html", "data.json", "logo.svg", "app.wasm"} {
+		assert.True(t, compressible(name), name)
+	}
+	for _, name := range []string{"assets/logo.png", "fonts/inter.woff2", "assets/main.js.gz", "LICENSE"} {
+		assert.False(t, compressible(name), name)
+	}
+}
+
+func TestAssetServer_Precompressed(t *testing.T) {
+	server := newTestAssetServer(true, 0, false)
+	server.fsys.(fstest.MapFS)["assets/main.js.br"] = &fstest.MapFile{Data: []byte("brotli")}
+	server.fsys.(fstest.MapFS)["assets/main.js.gz"] = &fstest.MapFile{Data: []byte("gzip")}
+
+	w := serveTestAsset(server, nethttp.MethodGet, "assets/main.js", map[string]string{"Accept-Encoding": "gzip, deflate, br"})
+	assert.Equal(t, nethttp.StatusOK, w.Code)
+	assert.Equal(t, "br", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+	assert.Equal(t, "text/javascript; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "public, max-age=31536000, immutable", w.Header().Get("Cache-Control"))
+	assert.Equal(t, "brotli", w.Body.String())
+	brotliETag := w.Header().Get("ETag")
+
+	w = serveTestAsset(server, nethttp.MethodGet, "assets/main.js", map[string]string{"Accept-Encoding": "gzip, br;q=0"})
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "gzip", w.Body.String())
+	assert.NotEqual(t, brotliETag, w.Header().Get("ETag"))
+
+	w = serveTestAsset(server, nethttp.MethodGet, "assets/main.js", nil)
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+	assert.Equal(t, `console.log("main")`, w.Body.String())
+
+	w = serveTestAsset(server, nethttp.MethodGet, "assets/main.css", map[string]string{"Accept-Encoding": "br"})
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "body{color:red}", w.Body.String())
+}
+
+func TestAssetServer_Compress(t *testing.T) {
+	server := newTestAssetServer(true, 0, true)
+	css := strings.Repeat("body{color:red}", 100)
+	server.fsys.(fstest.MapFS)["assets/main.css"] = &fstest.MapFile{Data: []byte(css)}
+	server.fsys.(fstest.MapFS)["assets/logo.png"] = &fstest.MapFile{Data: bytes.Repeat([]byte{0}, 100)}
+
+	for range 2 {
+		w := serveTestAsset(server, nethttp.MethodGet, "assets/main.css", map[string]string{"Accept-Encoding": "gzip"})
+		assert.Equal(t, nethttp.StatusOK, w.Code)
+		assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+		assert.Equal(t, "text/css; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Regexp(t, `^"[0-9a-f]{32}-gzip"$`, w.Header().Get("ETag"))
+		assert.Less(t, w.Body.Len(), len(css))
+
+		reader, err := gzip.NewReader(w.Body)
+		require.NoError(t, err)
+		data, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, css, string(data))
+	}
+
+	// Gzip would make the short file larger, so it is sent as is.
+	w := serveTestAsset(server, nethttp.MethodGet, "assets/main.js", map[string]string{"Accept-Encoding": "gzip"})
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, w.Header().Get("ETag"))
+	assert.Equal(t, `console.log("main")`, w.Body.String())
+
+	w = serveTestAsset(server, nethttp.MethodGet, "assets/logo.png", map[string]string{"Accept-Encoding": "gzip"})
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Equal(t, 100, w.Body.Len())
+}
+
+func TestAssetServer_CachesClearedOnReloadAndFlush(t *testing.T) {
+	server := newTestAssetServer(true, 0, true)
+	server.fsys.(fstest.MapFS)["assets/main.css"] = &fstest.MapFile{Data: []byte(strings.Repeat("body{color:red}", 100))}
+	vite := server.vite
+
+	cached := func() bool {
+		found := false
+		for _, cache := range []*sync.Map{&vite.etags, &vite.compressed} {
+			cache.Range(func(any, any) bool {
+				found = true
+				return false
+			})
+		}
+		return found
+	}
+
+	serveTestAsset(server, nethttp.MethodGet, "assets/main.css", map[string]string{"Accept-Encoding": "gzip"})
+	require.True(t, cached())
+	require.NoError(t, vite.Reload())
+	assert.False(t, cached())
+
+	serveTestAsset(server, nethttp.MethodGet, "assets/main.css", map[string]string{"Accept-Encoding": "gzip"})
+	require.True(t, cached())
+	vite.Flush()
+	assert.False(t, cached())
+}
@@ -0,0 +1,321 @@
+package vite
+
+import (
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/goravel/framework/contracts/config"
+	"github.com/goravel/framework/contracts/log"
+	"github.com/goravel/framework/support/path"
+)
+
+// Config is the vite configuration namespace, read once by LoadConfig. Start
+// from DefaultConfig when building one by hand: the zero value of several
+// fields, such as Probe.Enabled, is not their default.
+type Config struct {
+	// JSFramework is "vue" or "react".
+	JSFramework string
+	// EntryPoints are rendered by Assets when it is called without entries.
+	EntryPoints []string
+	// EntryGroups are named lists of entries, rendered by passing the name
+	// to Assets. Names may not contain a slash or a dot.
+	EntryGroups map[string][]string
+	// DevServerURL is used when the hot file does not name the dev server.
+	DevServerURL string
+	HotFile      string
+	Probe        ProbeConfig
+	DevProxy     bool
+
+	AssetsPath   string
+	ManifestPath string
+	// FS, when set, holds the build output instead of AssetsPath on disk.
+	FS          fs.FS
+	BaseURL     string
+	AssetURL    string
+	CrossOrigin string
+	ServeAssets bool
+
+	// CacheMaxAge applies to served files that are not in the manifest.
+	CacheMaxAge   time.Duration
+	HideManifest  bool
+	Precompressed bool
+	Compress      bool
+
+	Integrity string
+	SSR       SSRConfig
+
+	WatchManifest bool
+	WatchInterval time.Duration
+	WatchDebounce time.Duration
+
+	Prefetch PrefetchConfig
+	Preload  PreloadConfig
+	CSP      string
+
+	// Logger receives warnings such as entries missing from the manifest. It
+	// is not read from the config file; the service provider sets the
+	// application logger.
+	Logger log.Log
+}
+
+// ProbeConfig controls how a hot file naming an unreachable server is handled.
+type ProbeConfig struct {
+	Enabled  bool
+	Interval time.Duration
+	Timeout  time.Duration
+	// Fallback is "build" or "banner".
+	Fallback string
+}
+
+// SSRConfig configures rendering with the SSR process.
+type SSRConfig struct {
+	Enabled      bool
+	URL          string
+	Timeout      time.Duration
+	ManifestPath string
+}
+
+// PrefetchConfig controls prefetching of dynamically imported chunks.
+type PrefetchConfig struct {
+	// Strategy is "none", "waterfall" or "aggressive".
+	Strategy    string
+	Concurrency int
+}
+
+// PreloadConfig controls the PreloadHeaders middleware.
+type PreloadConfig struct {
+	MaxHints   int
+	EarlyHints bool
+}
+
+// DefaultConfig returns the configuration used when the vite namespace is
+// empty, matching the published config/vite.go.
+func DefaultConfig() Config {
+	return Config{
+		JSFramework:  "vue",
+		EntryPoints:  []string{"resources/js/main.ts"},
+		EntryGroups:  map[string][]string{},
+		DevServerURL: "http://localhost:5173",
+		HotFile:      "public/build/hot",
+		Probe: ProbeConfig{
+			Enabled:  true,
+			Interval: 2000 * time.Millisecond,
+			Timeout:  250 * time.Millisecond,
+			Fallback: "build",
+		},
+		AssetsPath:    "public/build",
+		ManifestPath:  "public/build/.vite/manifest.json",
+		BaseURL:       "/static",
+		CrossOrigin:   "anonymous",
+		ServeAssets:   true,
+		HideManifest:  true,
+		Precompressed: true,
+		SSR: SSRConfig{
+			URL:          "http://127.0.0.1:13714/render",
+			Timeout:      2000 * time.Millisecond,
+			ManifestPath: "public/build/.vite/ssr-manifest.json",
+		},
+		WatchInterval: 1000 * time.Millisecond,
+		WatchDebounce: 300 * time.Millisecond,
+		Prefetch: PrefetchConfig{
+			Strategy:    "none",
+			Concurrency: 3,
+		},
+		Preload: PreloadConfig{
+			MaxHints: 10,
+		},
+		CSP: defaultContentSecurityPolicy,
+	}
+}
+
+// LoadConfig reads the vite namespace, falling back to DefaultConfig for
+// missing keys.
+func LoadConfig(config config.Config) Config {
+	d := DefaultConfig()
+	millis := func(key string, def time.Duration) time.Duration {
+		return time.Duration(config.GetInt(key, int(def/time.Millisecond))) * time.Millisecond
+	}
+
+	c := Config{
+		JSFramework:  config.GetString("vite.js_framework", d.JSFramework),
+		EntryPoints:  entryList(config.Get("vite.entry_points", d.EntryPoints)),
+		EntryGroups:  entryGroupMap(config.Get("vite.entry_groups")),
+		DevServerURL: config.GetString("vite.dev_server_url", d.DevServerURL),
+		HotFile:      config.GetString("vite.hot_file", d.HotFile),
+		Probe: ProbeConfig{
+			Enabled:  config.GetBool("vite.probe.enabled", d.Probe.Enabled),
+			Interval: millis("vite.probe.interval", d.Probe.Interval),
+			Timeout:  millis("vite.probe.timeout", d.Probe.Timeout),
+			Fallback: config.GetString("vite.probe.fallback", d.Probe.Fallback),
+		},
+		DevProxy:      config.GetBool("vite.dev_proxy", d.DevProxy),
+		AssetsPath:    config.GetString("vite.assets_path", d.AssetsPath),
+		ManifestPath:  config.GetString("vite.manifest_path", d.ManifestPath),
+		BaseURL:       config.GetString("vite.base_url", d.BaseURL),
+		AssetURL:      config.GetString("vite.asset_url", d.AssetURL),
+		CrossOrigin:   config.GetString("vite.crossorigin", d.CrossOrigin),
+		ServeAssets:   config.GetBool("vite.serve_assets", d.ServeAssets),
+		CacheMaxAge:   time.Duration(config.GetInt("vite.cache_max_age", int(d.CacheMaxAge/time.Second))) * time.Second,
+		HideManifest:  config.GetBool("vite.hide_manifest", d.HideManifest),
+		Precompressed: config.GetBool("vite.precompressed", d.Precompressed),
+		Compress:      config.GetBool("vite.compress", d.Compress),
+		Integrity:     config.GetString("vite.integrity", d.Integrity),
+		SSR: SSRConfig{
+			Enabled:      config.GetBool("vite.ssr.enabled", d.SSR.Enabled),
+			URL:          config.GetString("vite.ssr.url", d.SSR.URL),
+			Timeout:      millis("vite.ssr.timeout", d.SSR.Timeout),
+			ManifestPath: config.GetString("vite.ssr.manifest_path", d.SSR.ManifestPath),
+		},
+		WatchManifest: config.GetBool("vite.watch_manifest", d.WatchManifest),
+		WatchInterval: millis("vite.watch_interval", d.WatchInterval),
+		WatchDebounce: millis("vite.watch_debounce", d.WatchDebounce),
+		Prefetch: PrefetchConfig{
+			Strategy:    config.GetString("vite.prefetch.strategy", d.Prefetch.Strategy),
+			Concurrency: config.GetInt("vite.prefetch.concurrency", d.Prefetch.Concurrency),
+		},
+		Preload: PreloadConfig{
+			MaxHints:   config.GetInt("vite.preload.max_hints", d.Preload.MaxHints),
+			EarlyHints: config.GetBool("vite.preload.early_hints", d.Preload.EarlyHints),
+		},
+		CSP: config.GetString("vite.csp", d.CSP),
+	}
+	if fsys, ok := config.Get("vite.fs").(fs.FS); ok {
+		c.FS = fsys
+	}
+
+	return c
+}
+
+// configError lists every invalid setting found by Validate.
+type configError []string
+
+func (e configError) Error() string {
+	return "invalid vite configuration:\n  - " + strings.Join(e, "\n  - ")
+}
+
+// Validate checks the values of the configuration, reporting every invalid
+// setting at once.
+func (c Config) Validate() error {
+	var problems configError
+	oneOf := func(key, env, value string, allowed ...string) {
+		for _, a := range allowed {
+			if value == a {
+				return
+			}
+		}
+		problems = append(problems, fmt.Sprintf("vite.%s (%s) must be %s, got %q", key, env, quoteList(allowed), value))
+	}
+	nonNegative := func(key, env string, value int64) {
+		if value < 0 {
+			problems = append(problems, fmt.Sprintf("vite.%s (%s) must not be negative, got %d", key, env, value))
+		}
+	}
+
+	oneOf("js_framework", "VITE_JS_FRAMEWORK", c.JSFramework, "vue", "react")
+
+	for name := range c.EntryGroups {
+		if !isGroupName(name) {
+			problems = append(problems, fmt.Sprintf("vite.entry_groups: group name %q must not contain a slash or a dot", name))
+		}
+	}
+
+	if !absoluteURL(c.DevServerURL) {
+		problems = append(problems, fmt.Sprintf("vite.dev_server_url (VITE_DEV_SERVER_URL) must be an absolute http(s) URL, got %q", c.DevServerURL))
+	}
+
+	if !strings.HasPrefix(c.BaseURL, "/") || strings.Contains(c.BaseURL, "://") {
+		problems = append(problems, fmt.Sprintf("vite.base_url (VITE_BASE_URL) must be a path starting with /, got %q; use vite.asset_url for absolute URLs", c.BaseURL))
+	}
+
+	if c.AssetURL != "" && !absoluteURL(c.AssetURL) && !strings.HasPrefix(c.AssetURL, "//") {
+		problems = append(problems, fmt.Sprintf("vite.asset_url (VITE_ASSET_URL) must be an absolute URL, got %q", c.AssetURL))
+	}
+
+	oneOf("crossorigin", "VITE_CROSSORIGIN", c.CrossOrigin, "", "anonymous", "use-credentials")
+	oneOf("integrity", "VITE_INTEGRITY", strings.ToLower(c.Integrity), "", "false", "none", "manifest", "sha256", "sha384", "sha512")
+	oneOf("probe.fallback", "VITE_PROBE_FALLBACK", strings.ToLower(c.Probe.Fallback), "build", "banner")
+	oneOf("prefetch.strategy", "VITE_PREFETCH_STRATEGY", strings.ToLower(c.Prefetch.Strategy), "none", "waterfall", "aggressive")
+
+	if c.SSR.Enabled && !absoluteURL(c.SSR.URL) {
+		problems = append(problems, fmt.Sprintf("vite.ssr.url (VITE_SSR_URL) must be an absolute http(s) URL, got %q", c.SSR.URL))
+	}
+
+	nonNegative("probe.interval", "VITE_PROBE_INTERVAL", c.Probe.Interval.Milliseconds())
+	nonNegative("probe.timeout", "VITE_PROBE_TIMEOUT", c.Probe.Timeout.Milliseconds())
+	nonNegative("cache_max_age", "VITE_CACHE_MAX_AGE", int64(c.CacheMaxAge/time.Second))
+	nonNegative("ssr.timeout", "VITE_SSR_TIMEOUT", c.SSR.Timeout.Milliseconds())
+	nonNegative("watch_debounce", "VITE_WATCH_DEBOUNCE", c.WatchDebounce.Milliseconds())
+	nonNegative("prefetch.concurrency", "VITE_PREFETCH_CONCURRENCY", int64(c.Prefetch.Concurrency))
+	nonNegative("preload.max_hints", "VITE_PRELOAD_MAX_HINTS", int64(c.Preload.MaxHints))
+
+	if c.WatchManifest && c.WatchInterval <= 0 {
+		problems = append(problems, fmt.Sprintf("vite.watch_interval (VITE_WATCH_INTERVAL) must be positive when vite.watch_manifest is enabled, got %d", c.WatchInterval.Milliseconds()))
+	}
+
+	if len(problems) > 0 {
+		return problems
+	}
+
+	return nil
+}
+
+// checkBuildPaths checks that the build the configuration points at exists,
+// as it must in production: the manifest, and the assets directory unless FS
+// holds the build.
+func (c Config) checkBuildPaths() error {
+	var problems configError
+
+	if c.FS != nil {
+		name := relativeToAssets(c.AssetsPath, c.ManifestPath)
+		if _, err := fs.Stat(c.FS, name); err != nil {
+			problems = append(problems, fmt.Sprintf("vite.manifest_path (VITE_MANIFEST_PATH): %s is not in vite.fs; build the frontend before compiling", name))
+		}
+	} else {
+		assetsPath := path.Base(c.AssetsPath)
+		if info, err := os.Stat(assetsPath); err != nil || !info.IsDir() {
+			problems = append(problems, fmt.Sprintf("vite.assets_path (VITE_ASSETS_PATH): directory %s does not exist; run the frontend build", assetsPath))
+		}
+
+		manifestPath := path.Base(c.ManifestPath)
+		if _, err := os.Stat(manifestPath); err != nil {
+			problems = append(problems, fmt.Sprintf("vite.manifest_path (VITE_MANIFEST_PATH): %s does not exist; run the frontend build with build.manifest enabled", manifestPath))
+		}
+	}
+
+	if len(problems) > 0 {
+		return problems
+	}
+
+	return nil
+}
+
+func absoluteURL(value string) bool {
+	u, err := url.Parse(value)
+
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func quoteList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = fmt.Sprintf("%q", value)
+	}
+
+	return "one of " + strings.Join(quoted, ", ")
+}
+
+// relativeToAssets maps a configured path to its name in a file system whose
+// root is assetsPath.
+func relativeToAssets(assetsPath, file string) string {
+	rel, err := filepath.Rel(filepath.Clean(assetsPath), filepath.Clean(file))
+	if err != nil {
+		return filepath.ToSlash(file)
+	}
+
+	return filepath.ToSlash(rel)
+}
@@ -17,16 +17,56 @@ func init() {
 		// Specifies the entry points for Vite. These are the files that Vite
 		// will start processing from. Typically, this includes your main JavaScript
 		// or TypeScript file and potentially a CSS file.
-		// split by comma
-		// e.g. "resources/js/main.ts,resources/css/app.css"
+		// Either a list, e.g. []string{"resources/js/main.ts", "resources/css/app.css"},
+		// or a comma-separated string as set through VITE_ENTRY_POINTS.
 		"entry_points": config.Env("VITE_ENTRY_POINTS", "resources/js/main.ts"),
 
+		// Entry Groups
+		//
+		// Named lists of entry points for pages that load a different set of
+		// files, e.g. "admin": []string{"resources/js/admin.ts"}. Pass the
+		// name instead of the entries to render a group: {{ vite "admin" }}.
+		// Names may not contain a slash or a dot.
+		"entry_groups": map[string]any{},
+
 		// Development Server URL
 		//
 		// The URL where the Vite development server is running. This is used
-		// by Goravel's Vite integration to load assets during development.
+		// by Goravel's Vite integration to load assets during development
+		// when the hot file does not contain a URL.
 		"dev_server_url": config.Env("VITE_DEV_SERVER_URL", "http://localhost:5173"),
 
+		// Hot File
+		//
+		// The file written by the Vite dev server when it starts and removed
+		// when it stops. Assets are loaded from the dev server only while this
+		// file exists; otherwise the built assets from the manifest are used.
+		"hot_file": config.Env("VITE_HOT_FILE", "public/build/hot"),
+
+		// Dev Server Probe
+		//
+		// A hot file left behind by a dev server that was killed would point
+		// every page at a dead server. While the hot file exists, the server
+		// is probed with a HEAD request for /@vite/client, at most once every
+		// interval milliseconds and waiting up to timeout milliseconds. When
+		// it does not answer, fallback decides what is rendered: "build" uses
+		// the manifest, "banner" shows an error explaining how to start it.
+		"probe": map[string]any{
+			"enabled":  config.Env("VITE_PROBE_ENABLED", true),
+			"interval": config.Env("VITE_PROBE_INTERVAL", 2000),
+			"timeout":  config.Env("VITE_PROBE_TIMEOUT", 250),
+			"fallback": config.Env("VITE_PROBE_FALLBACK", "build"),
+		},
+
+		// Dev Server Proxy
+		//
+		// When enabled, the service provider registers routes forwarding the
+		// dev server paths (/@vite, /@id, /@fs, /@react-refresh, /resources
+		// and /node_modules), including the HMR WebSocket, to the dev server,
+		// and tags point at the application's own origin. Use it when only
+		// the Goravel port is reachable, e.g. in containers or Codespaces.
+		"dev_proxy": config.Env("VITE_DEV_PROXY", false),
+
 		// Assets Path
 		//
 		// The public path where compiled assets will be stored. Vite will place
@@ -34,6 +74,15 @@ func init() {
 		// accessible.
 		"assets_path": config.Env("VITE_ASSETS_PATH", "public/build"),
 
+		// Assets File System
+		//
+		// An fs.FS holding the build output, such as a directory embedded
+		// with //go:embed, to ship a single self-contained binary. When set,
+		// the manifest and the files under base_url are read from it instead
+		// of the disk, and manifest_path and ssr.manifest_path are resolved
+		// relative to assets_path, which is the root of the file system.
+		"fs": nil,
+
 		// Manifest Path
 		//
 		// The path to the Vite manifest file. This file contains a mapping of
@@ -43,8 +92,108 @@ func init() {
 
 		// Base URL
 		//
-		// The base URL path for the assets when served in production. This is
-		// prefixed to the asset URLs generated by the Vite integration.
+		// The local path the built assets are served from in production. This
+		// is prefixed to the asset URLs generated by the Vite integration
+		// unless asset_url is set.
 		"base_url": config.Env("VITE_BASE_URL", "/static"),
+
+		// Asset URL
+		//
+		// An absolute URL, such as a CDN origin, the built assets are uploaded
+		// to. When set, generated tags point there instead of base_url and
+		// carry the crossorigin attribute below. Set serve_assets to false to
+		// stop registering the local static route.
+		"asset_url":    config.Env("VITE_ASSET_URL", ""),
+		"crossorigin":  config.Env("VITE_CROSSORIGIN", "anonymous"),
+		"serve_assets": config.Env("VITE_SERVE_ASSETS", true),
+
+		// Asset Caching
+		//
+		// Files referenced by the manifest have content-hashed names and are
+		// served with a one year immutable Cache-Control. Other files under
+		// base_url, such as those copied from Vite's public directory, are
+		// cached for cache_max_age seconds, or revalidated on every request
+		// when it is 0. The manifests and the hot file answer 404 unless
+		// hide_manifest is disabled.
+		"cache_max_age": config.Env("VITE_CACHE_MAX_AGE", 0),
+		"hide_manifest": config.Env("VITE_HIDE_MANIFEST", true),
+
+		// Compression
+		//
+		// When precompressed is enabled, a .br or .gz sibling written by a
+		// compression plugin is sent instead of the file to clients that
+		// accept that encoding. When compress is enabled, text files without
+		// a sibling are gzipped on first request and kept in memory.
+		"precompressed": config.Env("VITE_PRECOMPRESSED", true),
+		"compress":      config.Env("VITE_COMPRESS", false),
+
+		// Subresource Integrity
+		//
+		// Adds integrity and crossorigin attributes to the generated tags.
+		// Use "manifest" to read the hashes written by a Vite SRI plugin, or
+		// "sha256", "sha384" or "sha512" to hash the files under assets_path
+		// when the manifest is loaded. Leave empty to disable.
+		"integrity": config.Env("VITE_INTEGRITY", ""),
+
+		// Server-Side Rendering
+		//
+		// When enabled, pages are rendered by a Node SSR process listening on
+		// url (the Inertia SSR server by default). Requests that fail or take
+		// longer than timeout milliseconds fall back to client rendering. The
+		// SSR manifest, written by vite build --ssrManifest, is used to preload
+		// the chunks of the modules the SSR process reports as rendered.
+		"ssr": map[string]any{
+			"enabled":       config.Env("VITE_SSR_ENABLED", false),
+			"url":           config.Env("VITE_SSR_URL", "http://127.0.0.1:13714/render"),
+			"timeout":       config.Env("VITE_SSR_TIMEOUT", 2000),
+			"manifest_path": config.Env("VITE_SSR_MANIFEST_PATH", "public/build/.vite/ssr-manifest.json"),
+		},
+
+		// Inertia
+		//
+		// Settings for the inertia.ServiceProvider. The root view is rendered
+		// on the first visit and receives the page object as "page".
+		"inertia": map[string]any{
+			"root_view": config.Env("VITE_INERTIA_ROOT_VIEW", "app.tmpl"),
+		},
+
+		// Manifest Watching
+		//
+		// When enabled, the manifest file is polled every watch_interval
+		// milliseconds and reloaded once a change has been stable for
+		// watch_debounce milliseconds, so a new build is picked up without
+		// restarting the server.
+		"watch_manifest": config.Env("VITE_WATCH_MANIFEST", false),
+		"watch_interval": config.Env("VITE_WATCH_INTERVAL", 1000),
+		"watch_debounce": config.Env("VITE_WATCH_DEBOUNCE", 300),
+
+		// Prefetching
+		//
+		// Chunks that are only loaded through dynamic imports, such as lazy
+		// routes, can be prefetched once the page has loaded. "waterfall"
+		// keeps concurrency requests in flight, "aggressive" requests them
+		// all at once and "none" disables prefetching.
+		"prefetch": map[string]any{
+			"strategy":    config.Env("VITE_PREFETCH_STRATEGY", "none"),
+			"concurrency": config.Env("VITE_PREFETCH_CONCURRENCY", 3),
+		},
+
+		// Preload Headers
+		//
+		// Settings for the vite.PreloadHeaders middleware, which sends the
+		// chunks and stylesheets of a page as Link headers. At most max_hints
+		// links are sent (0 sends all of them), and with early_hints enabled
+		// they are also sent in a 103 Early Hints response.
+		"preload": map[string]any{
+			"max_hints":   config.Env("VITE_PRELOAD_MAX_HINTS", 10),
+			"early_hints": config.Env("VITE_PRELOAD_EARLY_HINTS", false),
+		},
+
+		// Content Security Policy
+		//
+		// The policy sent by the vite.ContentSecurityPolicy middleware. The
+		// {nonce} placeholder is replaced with the nonce of the current
+		// request and {dev_server} with the dev server origins while it runs.
+		"csp": config.Env("VITE_CSP", "default-src 'self'; script-src 'self' {nonce} {dev_server}; style-src 'self' 'unsafe-inline' {dev_server}; img-src 'self' data: {dev_server}; font-src 'self' data: {dev_server}; connect-src 'self' {dev_server}"),
 	})
 }
@@ -0,0 +1,163 @@
+package vite
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	mocksconfig "github.com/goravel/framework/mocks/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newLoadConfigMock serves the given values of the vite namespace, and the
+// default passed by the caller for every other key.
+func newLoadConfigMock(t *testing.T, values map[string]any) *mocksconfig.Config {
+	lookup := func(key string, defaultValue ...any) any {
+		if value, ok := values[key]; ok {
+			return value
+		}
+		if len(defaultValue) > 0 {
+			return defaultValue[0]
+		}
+
+		return nil
+	}
+
+	mockConfig := mocksconfig.NewConfig(t)
+	mockConfig.EXPECT().Get(mock.Anything).RunAndReturn(lookup).Maybe()
+	mockConfig.EXPECT().Get(mock.Anything, mock.Anything).RunAndReturn(lookup).Maybe()
+	mockConfig.EXPECT().GetString(mock.Anything, mock.Anything).RunAndReturn(func(key string, defaultValue ...any) string {
+		return lookup(key, defaultValue...).(string)
+	}).Maybe()
+	mockConfig.EXPECT().GetInt(mock.Anything, mock.Anything).RunAndReturn(func(key string, defaultValue ...any) int {
+		return lookup(key, defaultValue...).(int)
+	}).Maybe()
+	mockConfig.EXPECT().GetBool(mock.Anything, mock.Anything).RunAndReturn(func(key string, defaultValue ...any) bool {
+		return lookup(key, defaultValue...).(bool)
+	}).Maybe()
+
+	return mockConfig
+}
+
+func TestLoadConfig_Defaults(t *testing.T) {
+	assert.Equal(t, DefaultConfig(), LoadConfig(newLoadConfigMock(t, nil)))
+	assert.Equal(t, DefaultConfig(), NewVite(newLoadConfigMock(t, nil)).config)
+}
+
+func TestLoadConfig(t *testing.T) {
+	fsys := fstest.MapFS{}
+	config := LoadConfig(newLoadConfigMock(t, map[string]any{
+		"vite.js_framework":         "react",
+		"vite.entry_points":         "resources/js/app.tsx, resources/css/app.css",
+		"vite.entry_groups":         map[string]any{"admin": []any{"resources/js/admin.tsx"}},
+		"vite.probe.interval":       1000,
+		"vite.probe.fallback":       "banner",
+		"vite.fs":                   fsys,
+		"vite.asset_url":            "https://cdn.example.com",
+		"vite.cache_max_age":        3600,
+		"vite.hide_manifest":        false,
+		"vite.ssr.enabled":          true,
+		"vite.ssr.timeout":          500,
+		"vite.watch_manifest":       true,
+		"vite.prefetch.strategy":    "waterfall",
+		"vite.prefetch.concurrency": 5,
+		"vite.preload.early_hints":  true,
+	}))
+
+	expected := DefaultConfig()
+	expected.JSFramework = "react"
+	expected.EntryPoints = []string{"resources/js/app.tsx", "resources/css/app.css"}
+	expected.EntryGroups = map[string][]string{"admin": {"resources/js/admin.tsx"}}
+	expected.Probe.Interval = time.Second
+	expected.Probe.Fallback = "banner"
+	expected.FS = fsys
+	expected.AssetURL = "https://cdn.example.com"
+	expected.CacheMaxAge = time.Hour
+	expected.HideManifest = false
+	expected.SSR.Enabled = true
+	expected.SSR.Timeout = 500 * time.Millisecond
+	expected.WatchManifest = true
+	expected.Prefetch = PrefetchConfig{Strategy: "waterfall", Concurrency: 5}
+	expected.Preload.EarlyHints = true
+
+	assert.Equal(t, expected, config)
+}
+
+// The vite namespace is only read by LoadConfig; everything else reads the
+// typed Config.
+func TestLoadConfig_OnlyReader(t *testing.T) {
+	key := regexp.MustCompile(`\.Get\w*\("vite\.`)
+
+	files, err := filepath.Glob("*.go")
+	require.NoError(t, err)
+
+	for _, file := range files {
+		if file == "config.go" || strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.False(t, key.Match(data), file)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	assert.NoError(t, DefaultConfig().Validate())
+
+	config := DefaultConfig()
+	config.JSFramework = "reactt"
+	config.DevServerURL = "localhost:5173"
+	config.BaseURL = "https://cdn.example.com"
+	config.Integrity = "md5"
+	config.Probe.Timeout = -time.Millisecond
+	config.SSR.Enabled = true
+	config.SSR.URL = "/render"
+
+	assert.EqualError(t, config.Validate(), `invalid vite configuration:
+  - vite.js_framework (VITE_JS_FRAMEWORK) must be one of "vue", "react", got "reactt"
+  - vite.dev_server_url (VITE_DEV_SERVER_URL) must be an absolute http(s) URL, got "localhost:5173"
+  - vite.base_url (VITE_BASE_URL) must be a path starting with /, got "https://cdn.example.com"; use vite.asset_url for absolute URLs
+  - vite.integrity (VITE_INTEGRITY) must be one of "", "false", "none", "manifest", "sha256", "sha384", "sha512", got "md5"
+  - vite.ssr.url (VITE_SSR_URL) must be an absolute http(s) URL, got "/render"
+  - vite.probe.timeout (VITE_PROBE_TIMEOUT) must not be negative, got -1`)
+}
+
+func TestConfig_ValidateBuild(t *testing.T) {
+	dir := t.TempDir()
+
+	config := DefaultConfig()
+	config.AssetsPath = filepath.Join(dir, "build")
+	config.ManifestPath = filepath.Join(dir, "build", ".vite", "manifest.json")
+	assert.EqualError(t, config.checkBuildPaths(), `invalid vite configuration:
+  - vite.assets_path (VITE_ASSETS_PATH): directory `+config.AssetsPath+` does not exist; run the frontend build
+  - vite.manifest_path (VITE_MANIFEST_PATH): `+config.ManifestPath+` does not exist; run the frontend build with build.manifest enabled`)
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(config.ManifestPath), 0755))
+	require.NoError(t, os.WriteFile(config.ManifestPath, []byte("{}"), 0644))
+	assert.NoError(t, config.checkBuildPaths())
+
+	config.FS = fstest.MapFS{}
+	assert.EqualError(t, config.checkBuildPaths(), `invalid vite configuration:
+  - vite.manifest_path (VITE_MANIFEST_PATH): .vite/manifest.json is not in vite.fs; build the frontend before compiling`)
+
+	config.FS = fstest.MapFS{".vite/manifest.json": {Data: []byte("{}")}}
+	assert.NoError(t, config.checkBuildPaths())
+}
+
+func TestNewViteWithConfig(t *testing.T) {
+	config := DefaultConfig()
+	config.HotFile = filepath.Join(t.TempDir(), "hot")
+	config.EntryPoints = []string{"resources/js/main.ts"}
+	config.FS = fstest.MapFS{
+		".vite/manifest.json": {Data: []byte(`{"resources/js/main.ts": {"file": "assets/main.js", "isEntry": true}}`)},
+	}
+
+	assert.Equal(t, `<link rel="modulepreload" href="/static/assets/main.js"><script type="module" src="/static/assets/main.js"></script>`, string(NewViteWithConfig(config).Assets()))
+}
@@ -0,0 +1,23 @@
+package contracts
+
+import (
+	"github.com/goravel/framework/contracts/http"
+)
+
+type Inertia interface {
+	// Version returns the current asset version.
+	Version() string
+	// Share adds a prop sent with every page.
+	Share(key string, value any)
+	// ShareWithContext adds a prop sent with every page of the current request.
+	ShareWithContext(ctx http.Context, key string, value any)
+	// Render renders the given page component with its props.
+	Render(ctx http.Context, component string, props map[string]any) http.Response
+	// Location redirects to the given URL with a full page visit.
+	Location(ctx http.Context, url string) http.Response
+	// Redirect redirects to the given location, using 303 for PUT, PATCH and
+	// DELETE requests.
+	Redirect(ctx http.Context, location string) http.Response
+	// Middleware handles asset version conflicts.
+	Middleware() http.Middleware
+}
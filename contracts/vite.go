@@ -1,7 +1,43 @@
 package contracts
 
-import "html/template"
+import (
+	"context"
+	"html/template"
+
+	"github.com/goravel/framework/contracts/http"
+)
 
 type Vite interface {
-	Assets() template.HTML
+	// Assets renders the script and link tags for the given entries, or for
+	// the configured entry points when none are given.
+	Assets(entries ...string) template.HTML
+	// AssetsWithNonce renders the same tags as Assets, carrying the CSP nonce
+	// of the current request.
+	AssetsWithNonce(ctx http.Context, entries ...string) template.HTML
+	// Nonce returns the CSP nonce of the current request.
+	Nonce(ctx http.Context) string
+	// ReactRefresh renders the React Refresh preamble while the dev server
+	// is running.
+	ReactRefresh() template.HTML
+	// Asset returns the URL of a source file processed by Vite, such as an
+	// image or font.
+	Asset(path string) (string, error)
+	// SSR renders the page with the SSR process. It returns nil when SSR is
+	// disabled or fails, in which case the page is rendered on the client.
+	SSR(ctx context.Context, page any) (*SSRResponse, error)
+	// ManifestHash returns a hash of the loaded manifest.
+	ManifestHash() (string, error)
+	// Reload reads the manifest again and swaps it in.
+	Reload() error
+	// Flush drops the cached manifest, dev server probe and served file caches.
+	Flush()
+}
+
+// SSRResponse is the markup rendered by the SSR process.
+type SSRResponse struct {
+	// Head holds the elements to add to the document head, including
+	// preload tags for the chunks used by the page.
+	Head template.HTML
+	// Body holds the rendered application markup.
+	Body template.HTML
 }
@@ -0,0 +1,247 @@
+package vite
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	nethttp "net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/contracts/foundation"
+)
+
+const (
+	devServerStartTimeout = 30 * time.Second
+	devServerStopTimeout  = 5 * time.Second
+)
+
+// lockfiles maps the lockfile of each supported package manager to its
+// executable, in the order they are looked up.
+var lockfiles = []struct {
+	file    string
+	manager string
+}{
+	{"bun.lock", "bun"},
+	{"bun.lockb", "bun"},
+	{"pnpm-lock.yaml", "pnpm"},
+	{"yarn.lock", "yarn"},
+	{"package-lock.json", "npm"},
+}
+
+// DevCommand runs the dev script of the frontend and the Goravel HTTP server
+// in one process, stopping both on SIGINT or SIGTERM.
+type DevCommand struct {
+	app foundation.Application
+}
+
+func NewDevCommand(app foundation.Application) *DevCommand {
+	return &DevCommand{app: app}
+}
+
+// Signature The name and signature of the console command.
+func (receiver *DevCommand) Signature() string {
+	return "vite:dev"
+}
+
+// Description The console command description.
+func (receiver *DevCommand) Description() string {
+	return "Run the Vite dev server together with the Goravel server"
+}
+
+// Extend The console command extend.
+func (receiver *DevCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "vite",
+		Flags: []command.Flag{
+			&command.BoolFlag{
+				Name:  "no-server",
+				Usage: "Only run the Vite dev server",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (receiver *DevCommand) Handle(ctx console.Context) error {
+	instance, err := receiver.app.Make(Binding)
+	if err != nil {
+		return &exitError{message: err.Error(), code: 1}
+	}
+	vite := instance.(*Vite)
+
+	signals, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	dir := receiver.app.BasePath()
+	manager := packageManager(dir)
+	output := newPrefixWriter(os.Stdout, "[vite] ")
+	defer output.Flush()
+
+	cmd := exec.Command(manager, "run", "dev")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "FORCE_COLOR=1")
+	cmd.Stdout = output
+	cmd.Stderr = output
+
+	ctx.Info(fmt.Sprintf("Starting %s run dev", manager))
+	if err := cmd.Start(); err != nil {
+		return &exitError{message: fmt.Sprintf("failed to start %s: %v", manager, err), code: 1}
+	}
+
+	exited := make(chan struct{})
+	go func() {
+		_ = cmd.Wait()
+		close(exited)
+	}()
+
+	viteDevServer, err := waitForDevServer(signals, vite.devServerURL, exited, devServerStartTimeout)
+	if err != nil {
+		stopProcess(cmd.Process, exited)
+		if signals.Err() != nil {
+			return nil
+		}
+		return &exitError{message: err.Error(), code: 1}
+	}
+	ctx.Info("Vite dev server ready at " + viteDevServer)
+
+	serverErr := make(chan error, 1)
+	if !ctx.OptionBool("no-server") {
+		route := receiver.app.MakeRoute()
+		go func() {
+			if err := route.Run(); err != nil && !errors.Is(err, nethttp.ErrServerClosed) {
+				serverErr <- err
+			}
+		}()
+		defer func() {
+			_ = route.Shutdown()
+		}()
+	}
+
+	select {
+	case <-signals.Done():
+		ctx.Info("Stopping the Vite dev server")
+		stopProcess(cmd.Process, exited)
+		return nil
+	case <-exited:
+		return &exitError{message: fmt.Sprintf("%s run dev exited with code %d", manager, cmd.ProcessState.ExitCode()), code: 1}
+	case err := <-serverErr:
+		stopProcess(cmd.Process, exited)
+		return &exitError{message: fmt.Sprintf("failed to start the HTTP server: %v", err), code: 1}
+	}
+}
+
+// packageManager picks the package manager whose lockfile is in dir, falling
+// back to npm.
+func packageManager(dir string) string {
+	for _, lockfile := range lockfiles {
+		if _, err := os.Stat(filepath.Join(dir, lockfile.file)); err == nil {
+			return lockfile.manager
+		}
+	}
+
+	return "npm"
+}
+
+// devServerURL returns the URL named by the hot file, which differs from
+// vite.dev_server_url when Vite had to pick another port.
+func (v *Vite) devServerURL() string {
+	if url, hot := v.hotFile(); hot {
+		return url
+	}
+
+	return v.config.DevServerURL
+}
+
+// waitForDevServer probes the dev server until it answers, giving up when the
+// process exits, ctx is cancelled or timeout elapses.
+func waitForDevServer(ctx context.Context, url func() string, exited <-chan struct{}, timeout time.Duration) (string, error) {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		viteDevServer := url()
+		if probeDevServer(viteDevServer, 250*time.Millisecond) {
+			return viteDevServer, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-exited:
+			return "", errors.New("the dev script exited before the dev server started")
+		case <-deadline.C:
+			return "", fmt.Errorf("the dev server did not answer at %s within %s", viteDevServer, timeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// stopProcess interrupts the process so Vite can remove its hot file, and
+// kills it when it has not exited after devServerStopTimeout.
+func stopProcess(process *os.Process, exited <-chan struct{}) {
+	if err := process.Signal(os.Interrupt); err != nil {
+		_ = process.Kill()
+	}
+
+	select {
+	case <-exited:
+	case <-time.After(devServerStopTimeout):
+		_ = process.Kill()
+		<-exited
+	}
+}
+
+// prefixWriter writes every complete line it receives to w with a prefix,
+// holding back a trailing partial line until it is completed or flushed.
+type prefixWriter struct {
+	mu     sync.Mutex
+	w      io.Writer
+	prefix []byte
+	buf    []byte
+}
+
+func newPrefixWriter(w io.Writer, prefix string) *prefixWriter {
+	return &prefixWriter{w: w, prefix: []byte(prefix)}
+}
+
+func (p *prefixWriter) Write(data []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.buf = append(p.buf, data...)
+	for {
+		i := bytes.IndexByte(p.buf, '\n')
+		if i < 0 {
+			break
+		}
+		if _, err := p.w.Write(append(append([]byte{}, p.prefix...), p.buf[:i+1]...)); err != nil {
+			return 0, err
+		}
+		p.buf = p.buf[i+1:]
+	}
+
+	return len(data), nil
+}
+
+// Flush writes the pending partial line, if any.
+func (p *prefixWriter) Flush() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.buf) > 0 {
+		_, _ = p.w.Write(append(append(append([]byte{}, p.prefix...), p.buf...), '\n'))
+		p.buf = nil
+	}
+}
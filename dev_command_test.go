@@ -0,0 +1,91 @@
+package vite
+
+import (
+	"bytes"
+	"context"
+	nethttp "net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageManager(t *testing.T) {
+	tests := []struct {
+		lockfiles []string
+		expected  string
+	}{
+		{nil, "npm"},
+		{[]string{"package-lock.json"}, "npm"},
+		{[]string{"yarn.lock"}, "yarn"},
+		{[]string{"pnpm-lock.yaml"}, "pnpm"},
+		{[]string{"bun.lockb"}, "bun"},
+		{[]string{"package-lock.json", "bun.lock"}, "bun"},
+	}
+
+	for _, test := range tests {
+		dir := t.TempDir()
+		for _, lockfile := range test.lockfiles {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, lockfile), []byte(""), 0644))
+		}
+
+		assert.Equal(t, test.expected, packageManager(dir), test.lockfiles)
+	}
+}
+
+func TestPrefixWriter(t *testing.T) {
+	var out bytes.Buffer
+	writer := newPrefixWriter(&out, "[vite] ")
+
+	data := []byte("VITE v6.0.0 ready\n  ➜  Local: ")
+	n, err := writer.Write(data)
+	require.NoError(t, err)
+	assert.Equal(t, len(data), n)
+	assert.Equal(t, "[vite] VITE v6.0.0 ready\n", out.String())
+
+	_, err = writer.Write([]byte("http://localhost:5173/\n\nhmr update"))
+	require.NoError(t, err)
+	writer.Flush()
+
+	assert.Equal(t, "[vite] VITE v6.0.0 ready\n[vite]   ➜  Local: http://localhost:5173/\n[vite] \n[vite] hmr update\n", out.String())
+}
+
+func TestWaitForDevServer(t *testing.T) {
+	devServer := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {}))
+	defer devServer.Close()
+
+	urls := []string{closedServerURL(), devServer.URL}
+	url := func() string {
+		next := urls[0]
+		if len(urls) > 1 {
+			urls = urls[1:]
+		}
+		return next
+	}
+
+	viteDevServer, err := waitForDevServer(context.Background(), url, make(chan struct{}), time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, devServer.URL, viteDevServer)
+}
+
+func TestWaitForDevServer_GivesUp(t *testing.T) {
+	url := closedServerURL()
+	urlFunc := func() string { return url }
+
+	exited := make(chan struct{})
+	close(exited)
+	_, err := waitForDevServer(context.Background(), urlFunc, exited, time.Second)
+	assert.EqualError(t, err, "the dev script exited before the dev server started")
+
+	_, err = waitForDevServer(context.Background(), urlFunc, make(chan struct{}), 150*time.Millisecond)
+	assert.EqualError(t, err, "the dev server did not answer at "+url+" within 150ms")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = waitForDevServer(ctx, urlFunc, make(chan struct{}), time.Second)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,215 @@
+package vite
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/contracts/foundation"
+	"github.com/goravel/framework/support/path"
+)
+
+const (
+	checkPass = "PASS"
+	checkWarn = "WARN"
+	checkFail = "FAIL"
+)
+
+// DoctorCommand checks the Vite configuration, the manifest and the built
+// files, and exits with a non-zero code when a check fails.
+type DoctorCommand struct {
+	app foundation.Application
+}
+
+func NewDoctorCommand(app foundation.Application) *DoctorCommand {
+	return &DoctorCommand{app: app}
+}
+
+// Signature The name and signature of the console command.
+func (receiver *DoctorCommand) Signature() string {
+	return "vite:doctor"
+}
+
+// Description The console command description.
+func (receiver *DoctorCommand) Description() string {
+	return "Check the Vite configuration, manifest and built assets"
+}
+
+// Extend The console command extend.
+func (receiver *DoctorCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "vite",
+	}
+}
+
+// Handle Execute the console command.
+func (receiver *DoctorCommand) Handle(ctx console.Context) error {
+	instance, err := receiver.app.Make(Binding)
+	if err != nil {
+		return &exitError{message: err.Error(), code: 1}
+	}
+
+	var failed int
+	for _, check := range instance.(*Vite).diagnose() {
+		ctx.TwoColumnDetail(check.name, check.status)
+		if check.status != checkPass && check.hint != "" {
+			ctx.Line("  " + check.hint)
+		}
+		if check.status == checkFail {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return &exitError{message: fmt.Sprintf("vite:doctor found %d failing check(s)", failed), code: 1}
+	}
+
+	ctx.Success("Everything looks good.")
+
+	return nil
+}
+
+// exitError makes artisan exit with code instead of panicking on the error.
+type exitError struct {
+	message string
+	code    int
+}
+
+func (e *exitError) Error() string {
+	return e.message
+}
+
+func (e *exitError) ExitCode() int {
+	return e.code
+}
+
+type doctorCheck struct {
+	name   string
+	status string
+	hint   string
+}
+
+// diagnose runs every check against the configuration and the files on disk,
+// bypassing the cached manifest. Build checks only warn while the dev server
+// is running, since a build is not needed then.
+func (v *Vite) diagnose() []doctorCheck {
+	var checks []doctorCheck
+
+	buildStatus := checkFail
+	if viteDevServer, hot := v.hotFile(); !hot {
+		checks = append(checks, doctorCheck{name: "Dev server not running, using the build", status: checkPass})
+	} else if probeDevServer(viteDevServer, time.Second) {
+		checks = append(checks, doctorCheck{name: "Dev server responding at " + viteDevServer, status: checkPass})
+		buildStatus = checkWarn
+	} else {
+		checks = append(checks, doctorCheck{
+			name:   "Dev server responding at " + viteDevServer,
+			status: checkFail,
+			hint:   "Start it with npm run dev, or delete the stale hot file " + path.Base(v.config.HotFile) + ".",
+		})
+	}
+
+	entries := v.allEntryPoints()
+
+	if len(entries) == 0 {
+		checks = append(checks, doctorCheck{name: "Entry points configured", status: checkFail, hint: "Set VITE_ENTRY_POINTS to a comma-separated list of entry files, e.g. resources/js/main.ts."})
+	} else {
+		checks = append(checks, doctorCheck{name: "Entry points configured", status: checkPass})
+	}
+
+	// Sources are not needed to run an embedded build.
+	sourceStatus := checkFail
+	if v.config.FS != nil {
+		sourceStatus = checkWarn
+	}
+	for _, entry := range entries {
+		check := doctorCheck{name: "Entry point " + entry + " exists", status: checkPass}
+		if _, err := os.Stat(path.Base(entry)); err != nil {
+			check.status = sourceStatus
+			check.hint = "Create the file or fix VITE_ENTRY_POINTS."
+		}
+		checks = append(checks, check)
+	}
+
+	baseURL := v.config.BaseURL
+	check := doctorCheck{name: "Base URL " + baseURL + " is a local path", status: checkPass}
+	if !strings.HasPrefix(baseURL, "/") || strings.Contains(baseURL, "://") {
+		check.status = checkFail
+		check.hint = "VITE_BASE_URL is the prefix of the local static route; use VITE_ASSET_URL for a CDN or other absolute URL."
+	}
+	checks = append(checks, check)
+
+	// An embedded build has no assets directory on disk.
+	assetsPath := path.Base(v.config.AssetsPath)
+	if v.config.FS != nil {
+		assetsPath = "vite.fs"
+	} else {
+		check = doctorCheck{name: "Assets path " + assetsPath + " exists", status: checkPass}
+		if info, err := os.Stat(assetsPath); err != nil || !info.IsDir() {
+			check.status = buildStatus
+			check.hint = "Run npm run build, or set VITE_ASSETS_PATH to Vite's build.outDir."
+		}
+		checks = append(checks, check)
+	}
+
+	build, err := v.readManifest()
+	if err != nil {
+		return append(checks, doctorCheck{
+			name:   "Manifest loads",
+			status: buildStatus,
+			hint:   fmt.Sprintf("%v. Run npm run build with build.manifest enabled, or fix VITE_MANIFEST_PATH.", err),
+		})
+	}
+	checks = append(checks, doctorCheck{name: "Manifest loads", status: checkPass})
+
+	for _, entry := range entries {
+		check := doctorCheck{name: "Entry point " + entry + " is in the manifest", status: checkPass}
+		if _, ok := build.manifest[entry]; !ok {
+			check.status = buildStatus
+			check.hint = "Add it to build.rollupOptions.input in vite.config.ts and rebuild."
+		}
+		checks = append(checks, check)
+	}
+
+	var missingImports []string
+	for key, entry := range build.manifest {
+		for _, imp := range entry.Imports {
+			if _, ok := build.manifest[imp]; !ok {
+				missingImports = append(missingImports, key+" -> "+imp)
+			}
+		}
+	}
+
+	check = doctorCheck{name: "Built files exist under " + assetsPath, status: checkPass}
+	if missingFiles := v.missingFiles(build); len(missingFiles) > 0 {
+		check.status = buildStatus
+		check.hint = "Missing " + summarize(missingFiles) + ". Rebuild, or check that VITE_ASSETS_PATH matches build.outDir."
+	}
+	checks = append(checks, check)
+
+	check = doctorCheck{name: "Manifest imports resolve", status: checkPass}
+	if len(missingImports) > 0 {
+		check.status = buildStatus
+		check.hint = "Unknown " + summarize(missingImports) + ". The manifest is incomplete; rebuild."
+	}
+	checks = append(checks, check)
+
+	return checks
+}
+
+// summarize lists the first few items in sorted order, mentioning how many
+// were left out.
+func summarize(items []string) string {
+	sort.Strings(items)
+
+	const limit = 3
+	if len(items) <= limit {
+		return strings.Join(items, ", ")
+	}
+
+	return fmt.Sprintf("%s and %d more", strings.Join(items[:limit], ", "), len(items)-limit)
+}
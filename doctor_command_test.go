@@ -0,0 +1,145 @@
+package vite
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/foundation"
+	mocksconsole "github.com/goravel/framework/mocks/console"
+	mocksfoundation "github.com/goravel/framework/mocks/foundation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagnose(t *testing.T) {
+	tempDir := t.TempDir()
+	entry := filepath.Join(tempDir, "main.ts")
+	missingEntry := filepath.Join(tempDir, "admin.ts")
+	assetsPath := filepath.Join(tempDir, "build")
+	manifestPath := filepath.Join(assetsPath, ".vite", "manifest.json")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(assetsPath, ".vite"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(assetsPath, "assets"), 0755))
+	require.NoError(t, os.WriteFile(entry, []byte(""), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(assetsPath, "assets", "main.js"), []byte(""), 0644))
+	require.NoError(t, os.WriteFile(manifestPath, []byte(`{
+		"`+entry+`": {
+			"file": "assets/main.js",
+			"isEntry": true,
+			"css": ["assets/main.css"],
+			"imports": ["_vendor.js"]
+		}
+	}`), 0644))
+
+	config := testConfig(tempDir)
+	config.EntryPoints = []string{entry, missingEntry}
+	config.BaseURL = "https://cdn.example.com"
+	config.AssetsPath = assetsPath
+	config.ManifestPath = manifestPath
+
+	checks := NewViteWithConfig(config).diagnose()
+
+	assert.Equal(t, []doctorCheck{
+		{name: "Dev server not running, using the build", status: checkPass},
+		{name: "Entry points configured", status: checkPass},
+		{name: "Entry point " + entry + " exists", status: checkPass},
+		{name: "Entry point " + missingEntry + " exists", status: checkFail, hint: "Create the file or fix VITE_ENTRY_POINTS."},
+		{name: "Base URL https://cdn.example.com is a local path", status: checkFail, hint: "VITE_BASE_URL is the prefix of the local static route; use VITE_ASSET_URL for a CDN or other absolute URL."},
+		{name: "Assets path " + assetsPath + " exists", status: checkPass},
+		{name: "Manifest loads", status: checkPass},
+		{name: "Entry point " + entry + " is in the manifest", status: checkPass},
+		{name: "Entry point " + missingEntry + " is in the manifest", status: checkFail, hint: "Add it to build.rollupOptions.input in vite.config.ts and rebuild."},
+		{name: "Built files exist under " + assetsPath, status: checkFail, hint: "Missing assets/main.css. Rebuild, or check that VITE_ASSETS_PATH matches build.outDir."},
+		{name: "Manifest imports resolve", status: checkFail, hint: "Unknown " + entry + " -> _vendor.js. The manifest is incomplete; rebuild."},
+	}, checks)
+}
+
+func TestDiagnose_FS(t *testing.T) {
+	config := testConfig(t.TempDir())
+	config.EntryPoints = []string{"resources/js/main.ts"}
+	config.AssetsPath = "missing"
+	config.ManifestPath = "missing/.vite/manifest.json"
+	config.FS = fstest.MapFS{
+		".vite/manifest.json": {Data: []byte(`{"resources/js/main.ts": {"file": "assets/main.js", "isEntry": true, "css": ["assets/main.css"]}}`)},
+		"assets/main.js":      {Data: []byte("")},
+		"assets/main.css":     {Data: []byte("")},
+	}
+
+	checks := NewViteWithConfig(config).diagnose()
+
+	assert.Equal(t, []doctorCheck{
+		{name: "Dev server not running, using the build", status: checkPass},
+		{name: "Entry points configured", status: checkPass},
+		{name: "Entry point resources/js/main.ts exists", status: checkWarn, hint: "Create the file or fix VITE_ENTRY_POINTS."},
+		{name: "Base URL /static is a local path", status: checkPass},
+		{name: "Manifest loads", status: checkPass},
+		{name: "Entry point resources/js/main.ts is in the manifest", status: checkPass},
+		{name: "Built files exist under vite.fs", status: checkPass},
+		{name: "Manifest imports resolve", status: checkPass},
+	}, checks)
+}
+
+func TestDiagnose_StaleHotFile(t *testing.T) {
+	tempDir := t.TempDir()
+	config := testConfig(tempDir)
+	config.EntryPoints = nil
+	config.AssetsPath = filepath.Join(tempDir, "build")
+	hotFile := config.HotFile
+	require.NoError(t, os.WriteFile(hotFile, []byte(closedServerURL()), 0644))
+
+	checks := NewViteWithConfig(config).diagnose()
+
+	require.Len(t, checks, 5)
+	assert.Equal(t, checkFail, checks[0].status)
+	assert.Equal(t, "Start it with npm run dev, or delete the stale hot file "+hotFile+".", checks[0].hint)
+	assert.Equal(t, doctorCheck{name: "Entry points configured", status: checkFail, hint: "Set VITE_ENTRY_POINTS to a comma-separated list of entry files, e.g. resources/js/main.ts."}, checks[1])
+	assert.Equal(t, checkFail, checks[3].status)
+	assert.Equal(t, "Manifest loads", checks[4].name)
+	assert.Equal(t, checkFail, checks[4].status)
+}
+
+func TestDoctorCommand_ExitCode(t *testing.T) {
+	config := testConfig(t.TempDir())
+	config.EntryPoints = nil
+
+	mockApp := mocksfoundation.NewApplication(t)
+	mockCtx := mocksconsole.NewContext(t)
+
+	mockApp.EXPECT().Make(Binding).Return(NewViteWithConfig(config), nil).Once()
+
+	mockCtx.EXPECT().TwoColumnDetail(mock.Anything, mock.Anything).Times(5)
+	mockCtx.EXPECT().Line(mock.Anything).Twice()
+
+	err := NewDoctorCommand(mockApp).Handle(mockCtx)
+
+	var exitErr *exitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 1, exitErr.ExitCode())
+	assert.Equal(t, "vite:doctor found 2 failing check(s)", exitErr.Error())
+}
+
+func TestCommands_NotRegistered(t *testing.T) {
+	for name, newCommand := range map[string]func(foundation.Application) console.Command{
+		"vite:doctor":   func(app foundation.Application) console.Command { return NewDoctorCommand(app) },
+		"vite:build":    func(app foundation.Application) console.Command { return NewBuildCommand(app) },
+		"vite:manifest": func(app foundation.Application) console.Command { return NewManifestCommand(app) },
+		"vite:dev":      func(app foundation.Application) console.Command { return NewDevCommand(app) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockApp := mocksfoundation.NewApplication(t)
+			mockApp.EXPECT().Make(Binding).Return(nil, errors.New("binding not found")).Once()
+
+			err := newCommand(mockApp).Handle(mocksconsole.NewContext(t))
+
+			var exitErr *exitError
+			require.ErrorAs(t, err, &exitErr)
+			assert.Equal(t, 1, exitErr.ExitCode())
+			assert.Equal(t, "binding not found", exitErr.Error())
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package vite
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// entryList reads an entry_points value: a list, or a comma-separated string
+// as set through VITE_ENTRY_POINTS. Entries are trimmed and blanks dropped.
+func entryList(value any) []string {
+	var values []string
+	switch value := value.(type) {
+	case string:
+		values = strings.Split(value, ",")
+	case []string:
+		values = value
+	case []any:
+		for _, entry := range value {
+			if entry, ok := entry.(string); ok {
+				values = append(values, entry)
+			}
+		}
+	}
+
+	entries := []string{}
+	for _, entry := range values {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
+
+// entryGroupMap reads an entry_groups value, mapping each group name to its
+// entries.
+func entryGroupMap(value any) map[string][]string {
+	groups := make(map[string][]string)
+	switch value := value.(type) {
+	case map[string][]string:
+		for name, entries := range value {
+			groups[name] = entryList(entries)
+		}
+	case map[string]any:
+		for name, entries := range value {
+			groups[name] = entryList(entries)
+		}
+	}
+
+	return groups
+}
+
+// isGroupName reports whether an entry names a group rather than a file.
+// Entry files always contain a slash or an extension.
+func isGroupName(entry string) bool {
+	return !strings.ContainsAny(entry, "/.")
+}
+
+// resolveEntries returns the configured entry points when no entries are
+// given, and otherwise the given entries with groups expanded.
+func (v *Vite) resolveEntries(entries []string) []string {
+	if len(entries) == 0 {
+		return v.config.EntryPoints
+	}
+
+	var resolved []string
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !isGroupName(entry) {
+			resolved = append(resolved, entry)
+			continue
+		}
+
+		group, ok := v.config.EntryGroups[entry]
+		if !ok {
+			v.warn("vite: entry group %q is not defined in vite.entry_groups", entry)
+			continue
+		}
+		resolved = append(resolved, group...)
+	}
+
+	return resolved
+}
+
+// allEntryPoints returns the entry points followed by the entries of every
+// group, for the commands checking them against the build.
+func (v *Vite) allEntryPoints() []string {
+	entries := append([]string{}, v.config.EntryPoints...)
+
+	names := make([]string, 0, len(v.config.EntryGroups))
+	for name := range v.config.EntryGroups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	seen := make(map[string]bool)
+	for _, entry := range entries {
+		seen[entry] = true
+	}
+	for _, name := range names {
+		for _, entry := range v.config.EntryGroups[name] {
+			if !seen[entry] {
+				seen[entry] = true
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	return entries
+}
+
+// warn logs a problem once per message until the manifest is reloaded, so a
+// missing entry does not flood the log on every request. Nothing is logged
+// when no Config.Logger is set.
+func (v *Vite) warn(format string, args ...any) {
+	if v.config.Logger == nil {
+		return
+	}
+
+	message := fmt.Sprintf(format, args...)
+	if _, warned := v.warned.LoadOrStore(message, true); warned {
+		return
+	}
+
+	v.config.Logger.Warning(message)
+}
@@ -0,0 +1,119 @@
+package vite
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	mockslog "github.com/goravel/framework/mocks/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntryList(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected []string
+	}{
+		{nil, []string{}},
+		{"", []string{}},
+		{"resources/js/app.ts, resources/css/app.css ,", []string{"resources/js/app.ts", "resources/css/app.css"}},
+		{[]string{" resources/js/app.ts", ""}, []string{"resources/js/app.ts"}},
+		{[]any{"resources/js/app.ts", 1, "resources/css/app.css "}, []string{"resources/js/app.ts", "resources/css/app.css"}},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, entryList(test.value), test.value)
+	}
+}
+
+func TestEntryGroupMap(t *testing.T) {
+	assert.Equal(t, map[string][]string{}, entryGroupMap(nil))
+	assert.Equal(t, map[string][]string{
+		"admin":  {"resources/js/admin.ts", "resources/css/admin.css"},
+		"public": {"resources/js/public.ts"},
+	}, entryGroupMap(map[string]any{
+		"admin":  []any{"resources/js/admin.ts", "resources/css/admin.css"},
+		"public": "resources/js/public.ts",
+	}))
+}
+
+func newEntriesTestVite(t *testing.T) (*Vite, *mockslog.Log) {
+	config := DefaultConfig()
+	config.HotFile = filepath.Join(t.TempDir(), "hot")
+	config.EntryPoints = []string{"resources/js/app.ts", "resources/js/missing.ts"}
+	config.EntryGroups = map[string][]string{
+		"admin": {"resources/js/admin.ts", "resources/js/app.ts"},
+	}
+	config.FS = fstest.MapFS{
+		".vite/manifest.json": {Data: []byte(`{
+			"resources/js/app.ts": {"file": "assets/app.js", "isEntry": true},
+			"resources/js/admin.ts": {"file": "assets/admin.js", "isEntry": true, "css": ["assets/admin.css"]}
+		}`)},
+	}
+
+	mockLog := mockslog.NewLog(t)
+	config.Logger = mockLog
+
+	return NewViteWithConfig(config), mockLog
+}
+
+func TestResolveEntries(t *testing.T) {
+	vite, mockLog := newEntriesTestVite(t)
+	mockLog.EXPECT().Warning(`vite: entry group "billing" is not defined in vite.entry_groups`).Once()
+
+	assert.Equal(t, []string{"resources/js/app.ts", "resources/js/missing.ts"}, vite.resolveEntries(nil))
+	assert.Equal(t, []string{"resources/js/admin.ts", "resources/js/app.ts", "resources/js/main.ts"}, vite.resolveEntries([]string{"admin", " resources/js/main.ts "}))
+
+	for range 2 {
+		assert.Empty(t, vite.resolveEntries([]string{"billing"}))
+	}
+}
+
+func TestAssets_Groups(t *testing.T) {
+	vite, _ := newEntriesTestVite(t)
+
+	assert.Equal(t,
+		`<link rel="modulepreload" href="/static/assets/admin.js"><link rel="preload" href="/static/assets/admin.css" as="style"><link rel="modulepreload" href="/static/assets/app.js">`+
+			`<script type="module" src="/static/assets/admin.js"></script><link rel="stylesheet" href="/static/assets/admin.css"><script type="module" src="/static/assets/app.js"></script>`,
+		string(vite.Assets("admin")),
+	)
+}
+
+func TestAssets_WarnsAboutMissingEntries(t *testing.T) {
+	vite, mockLog := newEntriesTestVite(t)
+	mockLog.EXPECT().Warning(`vite: entry point "resources/js/missing.ts" is not in the manifest; add it to build.rollupOptions.input or fix vite.entry_points`).Twice()
+
+	expected := `<link rel="modulepreload" href="/static/assets/app.js"><script type="module" src="/static/assets/app.js"></script>`
+	for range 2 {
+		assert.Equal(t, expected, string(vite.Assets()))
+	}
+
+	// A reload may bring the entry in, so it is warned about again.
+	require.NoError(t, vite.Reload())
+	assert.Equal(t, expected, string(vite.Assets()))
+}
+
+func TestNewViteWithConfig_Logger(t *testing.T) {
+	config := testConfig(t.TempDir())
+	require.NoError(t, os.WriteFile(config.ManifestPath, []byte(`{"resources/js/app.ts": {"file": "assets/app.js", "isEntry": true}}`), 0644))
+
+	mockLog := mockslog.NewLog(t)
+	mockLog.EXPECT().Warning(`vite: entry point "resources/js/missing.ts" is not in the manifest; add it to build.rollupOptions.input or fix vite.entry_points`).Once()
+	config.Logger = mockLog
+
+	assert.Empty(t, string(NewViteWithConfig(config).Assets("resources/js/missing.ts")))
+}
+
+func TestAllEntryPoints(t *testing.T) {
+	vite := NewViteWithConfig(Config{
+		EntryPoints: []string{"resources/js/app.ts"},
+		EntryGroups: map[string][]string{
+			"public": {"resources/js/public.ts", "resources/js/app.ts"},
+			"admin":  {"resources/js/admin.ts"},
+		},
+	})
+
+	assert.Equal(t, []string{"resources/js/app.ts", "resources/js/admin.ts", "resources/js/public.ts"}, vite.allEntryPoints())
+}
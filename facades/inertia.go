@@ -0,0 +1,16 @@
+package facades
+
+import (
+	vite "github.com/merouanekhalili/goravel-vite"
+	"github.com/merouanekhalili/goravel-vite/contracts"
+	"github.com/merouanekhalili/goravel-vite/inertia"
+)
+
+func Inertia() (contracts.Inertia, error) {
+	instance, err := vite.App.Make(inertia.Binding)
+	if err != nil {
+		return nil, err
+	}
+
+	return instance.(contracts.Inertia), nil
+}
@@ -3,18 +3,17 @@ package facades
 import (
 	"testing"
 
-	mocksconfig "github.com/goravel/framework/mocks/config"
 	mocksfoundation "github.com/goravel/framework/mocks/foundation"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	vite "github.com/merouanekhalili/goravel-vite"
+	"github.com/merouanekhalili/goravel-vite/inertia"
 )
 
 func TestMakingSessionWithRealImplementation(t *testing.T) {
 
 	mockApp := mocksfoundation.NewApplication(t)
-	mockConfig := mocksconfig.NewConfig(t)
 
 	originalApp := vite.App
 	defer func() {
@@ -22,7 +21,7 @@ func TestMakingSessionWithRealImplementation(t *testing.T) {
 	}()
 	vite.App = mockApp
 
-	realViteInstance := vite.NewVite(mockConfig)
+	realViteInstance := vite.NewViteWithConfig(vite.DefaultConfig())
 
 	mockApp.EXPECT().Make(vite.Binding).
 		Return(realViteInstance, nil).Once()
@@ -33,3 +32,25 @@ func TestMakingSessionWithRealImplementation(t *testing.T) {
 	require.NotNil(t, instance)
 	assert.Equal(t, realViteInstance, instance)
 }
+
+func TestMakingInertiaWithRealImplementation(t *testing.T) {
+
+	mockApp := mocksfoundation.NewApplication(t)
+
+	originalApp := vite.App
+	defer func() {
+		vite.App = originalApp
+	}()
+	vite.App = mockApp
+
+	realInertiaInstance := inertia.NewInertia("app.tmpl", nil)
+
+	mockApp.EXPECT().Make(inertia.Binding).
+		Return(realInertiaInstance, nil).Once()
+
+	instance, err := Inertia()
+	require.NoError(t, err)
+
+	require.NotNil(t, instance)
+	assert.Equal(t, realInertiaInstance, instance)
+}
@@ -0,0 +1,223 @@
+package inertia
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"strings"
+	"sync"
+
+	"github.com/goravel/framework/contracts/http"
+
+	"github.com/merouanekhalili/goravel-vite/contracts"
+)
+
+const (
+	HeaderInertia          = "X-Inertia"
+	HeaderVersion          = "X-Inertia-Version"
+	HeaderLocation         = "X-Inertia-Location"
+	HeaderPartialComponent = "X-Inertia-Partial-Component"
+	HeaderPartialData      = "X-Inertia-Partial-Data"
+	HeaderPartialExcept    = "X-Inertia-Partial-Except"
+)
+
+// Props are the properties passed to a page component. Values of type
+// func() any are evaluated only when the prop is sent, and LazyProp values
+// are only sent when a partial reload asks for them.
+type Props map[string]any
+
+// LazyProp is a prop that is left out of the first visit and only evaluated
+// when a partial reload requests it by name.
+type LazyProp func() any
+
+// Lazy marks fn as a LazyProp.
+func Lazy(fn func() any) LazyProp {
+	return fn
+}
+
+// Page is the page object sent to the Inertia client.
+type Page struct {
+	Component string         `json:"component"`
+	Props     map[string]any `json:"props"`
+	URL       string         `json:"url"`
+	Version   string         `json:"version"`
+}
+
+type sharedContextKey struct{}
+
+var _ contracts.Inertia = &Inertia{}
+
+// SSRFunc renders a page object on the server.
+type SSRFunc func(ctx context.Context, page Page) (*contracts.SSRResponse, error)
+
+type Inertia struct {
+	rootView string
+	version  func() string
+	ssr      SSRFunc
+
+	mu     sync.RWMutex
+	shared Props
+}
+
+func NewInertia(rootView string, version func() string) *Inertia {
+	return &Inertia{
+		rootView: rootView,
+		version:  version,
+		shared:   Props{},
+	}
+}
+
+// Version returns the current asset version.
+func (i *Inertia) Version() string {
+	if i.version == nil {
+		return ""
+	}
+
+	return i.version()
+}
+
+// UseSSR renders first visits on the server with fn. When fn returns no
+// response the page is rendered on the client.
+func (i *Inertia) UseSSR(fn SSRFunc) {
+	i.ssr = fn
+}
+
+// Share adds a prop sent with every page.
+func (i *Inertia) Share(key string, value any) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.shared[key] = value
+}
+
+// ShareWithContext adds a prop sent with every page rendered for the current
+// request, such as the authenticated user.
+func (i *Inertia) ShareWithContext(ctx http.Context, key string, value any) {
+	shared := Props{}
+	if existing, ok := ctx.Value(sharedContextKey{}).(Props); ok {
+		maps.Copy(shared, existing)
+	}
+	shared[key] = value
+
+	ctx.WithValue(sharedContextKey{}, shared)
+}
+
+// Render responds with the page object as JSON to Inertia requests and with
+// the root view otherwise. The root view receives the JSON encoded page as
+// "page", to be written into the data-page attribute of the app element, and
+// the server rendered markup as "ssr" when SSR is in use.
+func (i *Inertia) Render(ctx http.Context, component string, props map[string]any) http.Response {
+	page := Page{
+		Component: component,
+		Props:     i.resolveProps(ctx, component, props),
+		URL:       ctx.Request().Origin().URL.RequestURI(),
+		Version:   i.Version(),
+	}
+
+	if IsInertia(ctx) {
+		return ctx.Response().
+			Header(HeaderInertia, "true").
+			Header("Vary", HeaderInertia).
+			Json(http.StatusOK, page)
+	}
+
+	data, err := json.Marshal(page)
+	if err != nil {
+		return ctx.Response().String(http.StatusInternalServerError, err.Error())
+	}
+
+	var ssr *contracts.SSRResponse
+	if i.ssr != nil {
+		ssr, _ = i.ssr(ctx, page)
+	}
+
+	return ctx.Response().View().Make(i.rootView, map[string]any{
+		"ctx":  ctx,
+		"page": string(data),
+		"ssr":  ssr,
+	})
+}
+
+// Location redirects to an external URL, or to a non-Inertia page, with a full
+// page visit.
+func (i *Inertia) Location(ctx http.Context, url string) http.Response {
+	if IsInertia(ctx) {
+		return ctx.Response().Header(HeaderLocation, url).String(http.StatusConflict, "")
+	}
+
+	return ctx.Response().Redirect(http.StatusFound, url)
+}
+
+// Redirect redirects to location, using 303 See Other for PUT, PATCH and
+// DELETE requests so the Inertia client follows up with a GET request.
+func (i *Inertia) Redirect(ctx http.Context, location string) http.Response {
+	switch ctx.Request().Method() {
+	case http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return ctx.Response().Redirect(http.StatusSeeOther, location)
+	default:
+		return ctx.Response().Redirect(http.StatusFound, location)
+	}
+}
+
+func (i *Inertia) resolveProps(ctx http.Context, component string, props map[string]any) map[string]any {
+	all := Props{}
+
+	i.mu.RLock()
+	maps.Copy(all, i.shared)
+	i.mu.RUnlock()
+
+	if shared, ok := ctx.Value(sharedContextKey{}).(Props); ok {
+		maps.Copy(all, shared)
+	}
+	maps.Copy(all, props)
+
+	var only, except map[string]bool
+	if IsInertia(ctx) && ctx.Request().Header(HeaderPartialComponent) == component {
+		only = splitHeader(ctx.Request().Header(HeaderPartialData))
+		except = splitHeader(ctx.Request().Header(HeaderPartialExcept))
+	}
+
+	resolved := make(map[string]any, len(all))
+	for key, value := range all {
+		if only != nil && !only[key] {
+			continue
+		}
+		if except[key] {
+			continue
+		}
+
+		switch prop := value.(type) {
+		case LazyProp:
+			if only == nil {
+				continue
+			}
+			resolved[key] = prop()
+		case func() any:
+			resolved[key] = prop()
+		default:
+			resolved[key] = value
+		}
+	}
+
+	return resolved
+}
+
+// IsInertia reports whether the request was made by the Inertia client.
+func IsInertia(ctx http.Context) bool {
+	return ctx.Request().Header(HeaderInertia) == "true"
+}
+
+func splitHeader(value string) map[string]bool {
+	if value == "" {
+		return nil
+	}
+
+	keys := make(map[string]bool)
+	for _, key := range strings.Split(value, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys[key] = true
+		}
+	}
+
+	return keys
+}
@@ -0,0 +1,281 @@
+package inertia
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/goravel/framework/contracts/http"
+	mockshttp "github.com/goravel/framework/mocks/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/merouanekhalili/goravel-vite/contracts"
+)
+
+type InertiaTestSuite struct {
+	suite.Suite
+
+	mockCtx      *mockshttp.Context
+	mockRequest  *mockshttp.ContextRequest
+	mockResponse *mockshttp.ContextResponse
+	inertia      *Inertia
+}
+
+func TestInertiaTestSuite(t *testing.T) {
+	suite.Run(t, new(InertiaTestSuite))
+}
+
+func (s *InertiaTestSuite) SetupTest() {
+	s.mockCtx = mockshttp.NewContext(s.T())
+	s.mockRequest = mockshttp.NewContextRequest(s.T())
+	s.mockResponse = mockshttp.NewContextResponse(s.T())
+	s.mockCtx.EXPECT().Request().Return(s.mockRequest).Maybe()
+	s.mockCtx.EXPECT().Response().Return(s.mockResponse).Maybe()
+
+	s.inertia = NewInertia("app.tmpl", func() string {
+		return "abc123"
+	})
+}
+
+func (s *InertiaTestSuite) expectRequest(method, target string, headers map[string]string) {
+	s.mockRequest.EXPECT().Method().Return(method).Maybe()
+	s.mockRequest.EXPECT().Origin().Return(httptest.NewRequest(method, target, nil)).Maybe()
+	s.mockRequest.EXPECT().Header(mock.Anything).RunAndReturn(func(key string, _ ...string) string {
+		return headers[key]
+	}).Maybe()
+}
+
+func (s *InertiaTestSuite) TestRender_FirstVisit() {
+	s.expectRequest(http.MethodGet, "/users?page=2", nil)
+	s.mockCtx.EXPECT().Value(sharedContextKey{}).Return(nil).Once()
+
+	s.inertia.Share("app", "Goravel")
+	s.inertia.Share("lazy", Lazy(func() any {
+		s.Fail("lazy prop should not be evaluated on the first visit")
+		return nil
+	}))
+
+	mockView := mockshttp.NewResponseView(s.T())
+	mockRendered := mockshttp.NewResponse(s.T())
+	s.mockResponse.EXPECT().View().Return(mockView).Once()
+	mockView.EXPECT().Make("app.tmpl", mock.Anything).RunAndReturn(func(view string, data ...any) http.Response {
+		s.Equal(s.mockCtx, data[0].(map[string]any)["ctx"], "the root view needs the context for the CSP nonce")
+
+		var page Page
+		s.Require().NoError(json.Unmarshal([]byte(data[0].(map[string]any)["page"].(string)), &page))
+		s.Equal(Page{
+			Component: "Users/Index",
+			Props:     map[string]any{"app": "Goravel", "users": []any{"Taylor"}, "count": float64(1)},
+			URL:       "/users?page=2",
+			Version:   "abc123",
+		}, page)
+
+		return mockRendered
+	}).Once()
+
+	response := s.inertia.Render(s.mockCtx, "Users/Index", Props{
+		"users": []string{"Taylor"},
+		"count": func() any { return 1 },
+	})
+
+	s.Equal(mockRendered, response)
+}
+
+func (s *InertiaTestSuite) TestRender_InertiaRequest() {
+	s.expectRequest(http.MethodGet, "/users", map[string]string{HeaderInertia: "true"})
+	s.mockCtx.EXPECT().Value(sharedContextKey{}).Return(Props{"auth": "user"}).Once()
+
+	mockJson := mockshttp.NewAbortableResponse(s.T())
+	s.mockResponse.EXPECT().Header(HeaderInertia, "true").Return(s.mockResponse).Once()
+	s.mockResponse.EXPECT().Header("Vary", HeaderInertia).Return(s.mockResponse).Once()
+	s.mockResponse.EXPECT().Json(http.StatusOK, Page{
+		Component: "Users/Index",
+		Props:     map[string]any{"auth": "user", "users": []string{"Taylor"}},
+		URL:       "/users",
+		Version:   "abc123",
+	}).Return(mockJson).Once()
+
+	response := s.inertia.Render(s.mockCtx, "Users/Index", Props{
+		"users": []string{"Taylor"},
+	})
+
+	s.Equal(mockJson, response)
+}
+
+func (s *InertiaTestSuite) TestRender_PartialReload() {
+	s.expectRequest(http.MethodGet, "/users", map[string]string{
+		HeaderInertia:          "true",
+		HeaderPartialComponent: "Users/Index",
+		HeaderPartialData:      "users, stats",
+		HeaderPartialExcept:    "stats",
+	})
+	s.mockCtx.EXPECT().Value(sharedContextKey{}).Return(nil).Once()
+
+	mockJson := mockshttp.NewAbortableResponse(s.T())
+	s.mockResponse.EXPECT().Header(mock.Anything, mock.Anything).Return(s.mockResponse).Twice()
+	s.mockResponse.EXPECT().Json(http.StatusOK, Page{
+		Component: "Users/Index",
+		Props:     map[string]any{"users": []string{"Taylor"}},
+		URL:       "/users",
+		Version:   "abc123",
+	}).Return(mockJson).Once()
+
+	s.inertia.Render(s.mockCtx, "Users/Index", Props{
+		"users":   Lazy(func() any { return []string{"Taylor"} }),
+		"stats":   func() any { return 1 },
+		"filters": "active",
+	})
+}
+
+func (s *InertiaTestSuite) TestRender_PartialReloadOfOtherComponent() {
+	s.expectRequest(http.MethodGet, "/users", map[string]string{
+		HeaderInertia:          "true",
+		HeaderPartialComponent: "Users/Show",
+		HeaderPartialData:      "users",
+	})
+	s.mockCtx.EXPECT().Value(sharedContextKey{}).Return(nil).Once()
+
+	mockJson := mockshttp.NewAbortableResponse(s.T())
+	s.mockResponse.EXPECT().Header(mock.Anything, mock.Anything).Return(s.mockResponse).Twice()
+	s.mockResponse.EXPECT().Json(http.StatusOK, Page{
+		Component: "Users/Index",
+		Props:     map[string]any{"filters": "active"},
+		URL:       "/users",
+		Version:   "abc123",
+	}).Return(mockJson).Once()
+
+	s.inertia.Render(s.mockCtx, "Users/Index", Props{
+		"users":   Lazy(func() any { return []string{"Taylor"} }),
+		"filters": "active",
+	})
+}
+
+func (s *InertiaTestSuite) TestShareWithContext() {
+	s.mockCtx.EXPECT().Value(sharedContextKey{}).Return(Props{"auth": "user"}).Once()
+	s.mockCtx.EXPECT().WithValue(sharedContextKey{}, Props{"auth": "user", "flash": "saved"}).Once()
+
+	s.inertia.ShareWithContext(s.mockCtx, "flash", "saved")
+}
+
+func (s *InertiaTestSuite) TestRedirect() {
+	for method, status := range map[string]int{
+		http.MethodGet:    http.StatusFound,
+		http.MethodPost:   http.StatusFound,
+		http.MethodPut:    http.StatusSeeOther,
+		http.MethodPatch:  http.StatusSeeOther,
+		http.MethodDelete: http.StatusSeeOther,
+	} {
+		s.SetupTest()
+		s.expectRequest(method, "/users/1", nil)
+
+		mockRedirect := mockshttp.NewAbortableResponse(s.T())
+		s.mockResponse.EXPECT().Redirect(status, "/users").Return(mockRedirect).Once()
+
+		s.Equal(mockRedirect, s.inertia.Redirect(s.mockCtx, "/users"), method)
+	}
+}
+
+func (s *InertiaTestSuite) TestLocation() {
+	s.expectRequest(http.MethodGet, "/login", map[string]string{HeaderInertia: "true"})
+
+	mockConflict := mockshttp.NewAbortableResponse(s.T())
+	s.mockResponse.EXPECT().Header(HeaderLocation, "https://example.com").Return(s.mockResponse).Once()
+	s.mockResponse.EXPECT().String(http.StatusConflict, "").Return(mockConflict).Once()
+
+	s.Equal(mockConflict, s.inertia.Location(s.mockCtx, "https://example.com"))
+
+	s.SetupTest()
+	s.expectRequest(http.MethodGet, "/login", nil)
+
+	mockRedirect := mockshttp.NewAbortableResponse(s.T())
+	s.mockResponse.EXPECT().Redirect(http.StatusFound, "https://example.com").Return(mockRedirect).Once()
+
+	s.Equal(mockRedirect, s.inertia.Location(s.mockCtx, "https://example.com"))
+}
+
+func (s *InertiaTestSuite) TestMiddleware_VersionConflict() {
+	s.expectRequest(http.MethodGet, "/users", map[string]string{
+		HeaderInertia: "true",
+		HeaderVersion: "old",
+	})
+	s.mockRequest.EXPECT().FullUrl().Return("http://localhost/users").Once()
+	s.mockResponse.EXPECT().Header("Vary", HeaderInertia).Return(s.mockResponse).Once()
+	s.mockResponse.EXPECT().Header(HeaderLocation, "http://localhost/users").Return(s.mockResponse).Once()
+	s.mockRequest.EXPECT().AbortWithStatus(http.StatusConflict).Once()
+
+	s.inertia.Middleware()(s.mockCtx)
+}
+
+func (s *InertiaTestSuite) TestMiddleware_Passes() {
+	for _, headers := range []map[string]string{
+		nil,
+		{HeaderInertia: "true", HeaderVersion: "abc123"},
+	} {
+		s.SetupTest()
+		s.expectRequest(http.MethodGet, "/users", headers)
+		s.mockResponse.EXPECT().Header("Vary", HeaderInertia).Return(s.mockResponse).Once()
+		s.mockRequest.EXPECT().Next().Once()
+
+		s.inertia.Middleware()(s.mockCtx)
+	}
+}
+
+func TestSplitHeader(t *testing.T) {
+	assert.Nil(t, splitHeader(""))
+	assert.Equal(t, map[string]bool{"a": true, "b": true}, splitHeader("a, b,,"))
+}
+
+func TestIsInertia(t *testing.T) {
+	mockCtx := mockshttp.NewContext(t)
+	mockRequest := mockshttp.NewContextRequest(t)
+	mockCtx.EXPECT().Request().Return(mockRequest).Once()
+	mockRequest.EXPECT().Header(HeaderInertia).Return("true").Once()
+
+	require.True(t, IsInertia(mockCtx))
+}
+
+func (s *InertiaTestSuite) TestRender_SSR() {
+	s.expectRequest(http.MethodGet, "/", nil)
+	s.mockCtx.EXPECT().Value(sharedContextKey{}).Return(nil).Once()
+
+	rendered := &contracts.SSRResponse{Head: "<title>Welcome</title>", Body: `<div id="app">Hello</div>`}
+	s.inertia.UseSSR(func(ctx context.Context, page Page) (*contracts.SSRResponse, error) {
+		s.Equal("Welcome", page.Component)
+		return rendered, nil
+	})
+
+	mockView := mockshttp.NewResponseView(s.T())
+	mockRendered := mockshttp.NewResponse(s.T())
+	s.mockResponse.EXPECT().View().Return(mockView).Once()
+	mockView.EXPECT().Make("app.tmpl", mock.Anything).RunAndReturn(func(view string, data ...any) http.Response {
+		s.Equal(rendered, data[0].(map[string]any)["ssr"])
+		return mockRendered
+	}).Once()
+
+	s.Equal(mockRendered, s.inertia.Render(s.mockCtx, "Welcome", nil))
+}
+
+func (s *InertiaTestSuite) TestRender_SSRFailureFallsBackToClient() {
+	s.expectRequest(http.MethodGet, "/", nil)
+	s.mockCtx.EXPECT().Value(sharedContextKey{}).Return(nil).Once()
+
+	s.inertia.UseSSR(func(ctx context.Context, page Page) (*contracts.SSRResponse, error) {
+		return nil, errors.New("connection refused")
+	})
+
+	mockView := mockshttp.NewResponseView(s.T())
+	mockRendered := mockshttp.NewResponse(s.T())
+	s.mockResponse.EXPECT().View().Return(mockView).Once()
+	mockView.EXPECT().Make("app.tmpl", mock.Anything).RunAndReturn(func(view string, data ...any) http.Response {
+		s.Nil(data[0].(map[string]any)["ssr"])
+		s.NotEmpty(data[0].(map[string]any)["page"])
+		return mockRendered
+	}).Once()
+
+	s.Equal(mockRendered, s.inertia.Render(s.mockCtx, "Welcome", nil))
+}
@@ -0,0 +1,22 @@
+package inertia
+
+import (
+	"github.com/goravel/framework/contracts/http"
+)
+
+// Middleware answers Inertia GET requests made with an outdated asset version
+// with 409 Conflict, so the client reloads the page and picks up the new
+// build.
+func (i *Inertia) Middleware() http.Middleware {
+	return func(ctx http.Context) {
+		ctx.Response().Header("Vary", HeaderInertia)
+
+		if IsInertia(ctx) && ctx.Request().Method() == http.MethodGet && ctx.Request().Header(HeaderVersion) != i.Version() {
+			ctx.Response().Header(HeaderLocation, ctx.Request().FullUrl())
+			ctx.Request().AbortWithStatus(http.StatusConflict)
+			return
+		}
+
+		ctx.Request().Next()
+	}
+}
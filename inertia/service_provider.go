@@ -0,0 +1,55 @@
+package inertia
+
+import (
+	"context"
+
+	"github.com/goravel/framework/contracts/foundation"
+
+	vite "github.com/merouanekhalili/goravel-vite"
+	"github.com/merouanekhalili/goravel-vite/contracts"
+)
+
+const Binding = "goravel.vite.inertia"
+
+type ServiceProvider struct {
+}
+
+func (receiver *ServiceProvider) Register(app foundation.Application) {
+	config := app.MakeConfig()
+
+	inertia := NewInertia(config.GetString("vite.inertia.root_view", "app.tmpl"), func() string {
+		instance, err := app.Make(vite.Binding)
+		if err != nil {
+			return ""
+		}
+
+		hash, err := instance.(contracts.Vite).ManifestHash()
+		if err != nil {
+			return ""
+		}
+
+		return hash
+	})
+
+	inertia.UseSSR(func(ctx context.Context, page Page) (*contracts.SSRResponse, error) {
+		instance, err := app.Make(vite.Binding)
+		if err != nil {
+			return nil, err
+		}
+
+		ssr, err := instance.(contracts.Vite).SSR(ctx, page)
+		if err != nil {
+			app.MakeLog().Warningf("inertia: falling back to client rendering: %v", err)
+		}
+
+		return ssr, err
+	})
+
+	app.Bind(Binding, func(app foundation.Application) (any, error) {
+		return inertia, nil
+	})
+}
+
+func (receiver *ServiceProvider) Boot(app foundation.Application) {
+
+}
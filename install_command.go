@@ -0,0 +1,356 @@
+package vite
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/contracts/foundation"
+)
+
+//go:embed all:templates config/vite.go
+var resources embed.FS
+
+// InstallCommand scaffolds the frontend of an application: it asks for the
+// stack, publishes the matching templates and writes the Vite settings to
+// .env.
+type InstallCommand struct {
+	app foundation.Application
+}
+
+func NewInstallCommand(app foundation.Application) *InstallCommand {
+	return &InstallCommand{app: app}
+}
+
+// Signature The name and signature of the console command.
+func (receiver *InstallCommand) Signature() string {
+	return "vite:install"
+}
+
+// Description The console command description.
+func (receiver *InstallCommand) Description() string {
+	return "Install the Vite configuration and frontend scaffolding"
+}
+
+// Extend The console command extend.
+func (receiver *InstallCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "vite",
+		Flags: []command.Flag{
+			&command.BoolFlag{
+				Name:    "force",
+				Aliases: []string{"f"},
+				Usage:   "Overwrite existing files",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (receiver *InstallCommand) Handle(ctx console.Context) error {
+	stack, err := askStack(ctx)
+	if err != nil {
+		return &exitError{message: fmt.Sprintf("failed to read the stack: %v", err), code: 1}
+	}
+
+	files := receiver.installFiles(stack)
+
+	if !ctx.OptionBool("force") {
+		var existing []string
+		for _, file := range files {
+			if _, err := os.Stat(file.dst); err == nil {
+				existing = append(existing, file.dst)
+			}
+		}
+
+		if len(existing) > 0 {
+			ctx.Error("The following files already exist, use --force to overwrite them:")
+			for _, file := range existing {
+				ctx.Line("  " + file)
+			}
+			return &exitError{message: fmt.Sprintf("vite:install found %d existing file(s)", len(existing)), code: 1}
+		}
+	}
+
+	for _, file := range files {
+		if err := file.write(); err != nil {
+			return &exitError{message: fmt.Sprintf("failed to write %s: %v", file.dst, err), code: 1}
+		}
+		ctx.Info("Created " + file.dst)
+	}
+
+	if err := writeEnv(receiver.app.BasePath(".env"), [][2]string{
+		{"VITE_JS_FRAMEWORK", stack.framework},
+		{"VITE_ENTRY_POINTS", stack.entryPoint()},
+	}); err != nil {
+		return &exitError{message: fmt.Sprintf("failed to update .env: %v", err), code: 1}
+	}
+
+	ctx.Success("Vite installed. Run npm install and npm run dev to start the dev server.")
+
+	return nil
+}
+
+// installStack is the frontend stack chosen during vite:install.
+type installStack struct {
+	framework  string
+	typescript bool
+	tailwind   bool
+	components bool
+}
+
+func askStack(ctx console.Context) (installStack, error) {
+	var stack installStack
+	var err error
+
+	stack.framework, err = ctx.Choice("Which framework do you want to use?", []console.Choice{
+		{Key: "React", Value: "react"},
+		{Key: "Vue", Value: "vue"},
+	}, console.ChoiceOption{Default: "react"})
+	if err != nil {
+		return stack, err
+	}
+
+	stack.typescript, err = ctx.Confirm("Do you want to use TypeScript?", console.ConfirmOption{Default: true})
+	if err != nil {
+		return stack, err
+	}
+
+	stack.tailwind, err = ctx.Confirm("Do you want to use Tailwind CSS?", console.ConfirmOption{Default: true})
+	if err != nil {
+		return stack, err
+	}
+
+	if stack.tailwind {
+		stack.components, err = ctx.Confirm("Do you want to set up shadcn components?", console.ConfirmOption{Default: true})
+		if err != nil {
+			return stack, err
+		}
+	}
+
+	return stack, nil
+}
+
+// scriptExtension returns the extension of the JavaScript sources, "tsx" or
+// "jsx" for React and "ts" or "js" for Vue.
+func (s installStack) scriptExtension() string {
+	extension := "js"
+	if s.typescript {
+		extension = "ts"
+	}
+	if s.framework == "react" {
+		extension += "x"
+	}
+
+	return extension
+}
+
+func (s installStack) entryPoint() string {
+	return "resources/js/main." + s.scriptExtension()
+}
+
+// installFile is a template copied to dst, passing its content through
+// transform first when it is set.
+type installFile struct {
+	src       string
+	dst       string
+	transform func(string) string
+}
+
+func (f installFile) write() error {
+	data, err := fs.ReadFile(resources, f.src)
+	if err != nil {
+		return err
+	}
+
+	content := string(data)
+	if f.transform != nil {
+		content = f.transform(content)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(f.dst), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(f.dst, []byte(content), 0644)
+}
+
+var (
+	tailwindPackages   = []string{"@tailwindcss/vite", "tailwindcss", "tailwindcss-animate", "prettier-plugin-tailwindcss", "@tailwindcss/oxide-linux-x64-gnu"}
+	componentsPackages = []string{"class-variance-authority", "clsx", "tailwind-merge", "tailwindcss-animate"}
+	typescriptPackages = []string{"typescript", "typescript-eslint", "@types/node", "@types/react", "@types/react-dom", "@vue/eslint-config-typescript", "vue-tsc"}
+)
+
+func (receiver *InstallCommand) installFiles(stack installStack) []installFile {
+	framework := stack.framework
+	extension := stack.scriptExtension()
+	base := receiver.app.BasePath
+
+	files := []installFile{
+		{src: "config/vite.go", dst: receiver.app.ConfigPath("vite.go")},
+		{src: "templates/.prettierignore.txt", dst: base(".prettierignore")},
+		{src: "templates/.prettierrc.txt", dst: base(".prettierrc"), transform: func(content string) string {
+			if stack.tailwind {
+				return content
+			}
+			content = strings.Replace(content, `, "prettier-plugin-tailwindcss"`, "", 1)
+			return removeJSONKeys(content, "tailwindFunctions")
+		}},
+		{src: "templates/" + framework + "/views/app.tmpl", dst: base("resources/views/app.tmpl")},
+		{src: "templates/" + framework + "/vite.config.ts.txt", dst: base("vite.config.ts"), transform: func(content string) string {
+			content = regexp.MustCompile(`resources/js/main\.tsx?`).ReplaceAllString(content, stack.entryPoint())
+			if !stack.tailwind {
+				content = removeLines(content, "tailwindcss")
+			}
+			return content
+		}},
+		{src: "templates/" + framework + "/package.json.txt", dst: base("package.json"), transform: func(content string) string {
+			if !stack.typescript {
+				content = removeJSONKeys(content, append([]string{"types"}, typescriptPackages...)...)
+			}
+			if !stack.tailwind {
+				content = removeJSONKeys(content, tailwindPackages...)
+			}
+			if !stack.components {
+				content = removeJSONKeys(content, componentsPackages...)
+			}
+			return content
+		}},
+		{src: "templates/" + framework + "/eslint.config.js.txt", dst: base("eslint.config.js"), transform: func(content string) string {
+			if stack.typescript {
+				return content
+			}
+			if framework == "react" {
+				return removeLines(content, "typescript")
+			}
+			return strings.NewReplacer(
+				"\nimport { defineConfigWithVueTs, vueTsConfigs } from '@vue/eslint-config-typescript';\n", "",
+				"defineConfigWithVueTs(\n    vue.configs['flat/essential'],\n    vueTsConfigs.recommended,", "[\n    ...vue.configs['flat/essential'],",
+				"            '@typescript-eslint/no-explicit-any': 'off',\n", "",
+				"    prettier,\n);", "    prettier,\n];",
+			).Replace(content)
+		}},
+	}
+
+	if stack.tailwind {
+		files = append(files, installFile{src: "templates/" + framework + "/css/app.css.txt", dst: base("resources/css/app.css"), transform: func(content string) string {
+			if !stack.components {
+				content = removeLines(content, "tailwindcss-animate")
+			}
+			return content
+		}})
+	} else {
+		files = append(files, installFile{src: "templates/css/app.css.txt", dst: base("resources/css/app.css")})
+	}
+
+	if stack.typescript {
+		files = append(files, installFile{src: "templates/" + framework + "/tsconfig.json.txt", dst: base("tsconfig.json")})
+	}
+
+	if stack.components {
+		files = append(files, installFile{src: "templates/" + framework + "/components.json.txt", dst: base("components.json"), transform: func(content string) string {
+			if stack.typescript {
+				return content
+			}
+			return strings.NewReplacer(`"tsx": true`, `"tsx": false`, `"typescript": true`, `"typescript": false`).Replace(content)
+		}})
+	}
+
+	if framework == "react" {
+		files = append(files,
+			installFile{src: "templates/react/js/App.tsx.txt", dst: base("resources/js/App." + extension)},
+			installFile{src: "templates/react/js/main.tsx.txt", dst: base("resources/js/main." + extension)},
+		)
+	} else {
+		files = append(files,
+			installFile{src: "templates/vue/js/App.vue.txt", dst: base("resources/js/App.vue"), transform: func(content string) string {
+				if stack.typescript {
+					return content
+				}
+				return strings.ReplaceAll(content, ` lang="ts"`, "")
+			}},
+			installFile{src: "templates/vue/js/main.ts.txt", dst: base("resources/js/main." + extension)},
+		)
+		if stack.typescript {
+			files = append(files, installFile{src: "templates/vue/js/env.d.ts.txt", dst: base("resources/js/env.d.ts")})
+		}
+	}
+
+	return files
+}
+
+// removeLines drops every line containing substr.
+func removeLines(content, substr string) string {
+	lines := strings.Split(content, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if !strings.Contains(line, substr) {
+			kept = append(kept, line)
+		}
+	}
+
+	return strings.Join(kept, "\n")
+}
+
+// removeJSONKeys drops the single-line members with the given keys from a
+// formatted JSON document, fixing up the comma left before a closing bracket.
+func removeJSONKeys(content string, keys ...string) string {
+	lines := strings.Split(content, "\n")
+	kept := make([]string, 0, len(lines))
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		removed := false
+		for _, key := range keys {
+			if strings.HasPrefix(trimmed, `"`+key+`":`) {
+				removed = true
+				break
+			}
+		}
+		if removed {
+			continue
+		}
+
+		if closing := strings.HasPrefix(trimmed, "}") || strings.HasPrefix(trimmed, "]"); closing && len(kept) > 0 {
+			kept[len(kept)-1] = strings.TrimSuffix(kept[len(kept)-1], ",")
+		}
+
+		kept = append(kept, line)
+	}
+
+	return strings.Join(kept, "\n")
+}
+
+// writeEnv sets the given variables in the env file, replacing existing
+// assignments in place and appending the others.
+func writeEnv(path string, values [][2]string) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	content := string(data)
+	for _, value := range values {
+		line := value[0] + "=" + value[1]
+		pattern := regexp.MustCompile(`(?m)^` + regexp.QuoteMeta(value[0]) + `=.*$`)
+
+		if pattern.MatchString(content) {
+			content = pattern.ReplaceAllLiteralString(content, line)
+			continue
+		}
+
+		if content != "" && !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		content += line + "\n"
+	}
+
+	return os.WriteFile(path, []byte(content), 0644)
+}
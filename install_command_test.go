@@ -0,0 +1,210 @@
+package vite
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goravel/framework/contracts/console"
+	mocksconsole "github.com/goravel/framework/mocks/console"
+	mocksfoundation "github.com/goravel/framework/mocks/foundation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newInstallTestApp(t *testing.T, dir string) *mocksfoundation.Application {
+	mockApp := mocksfoundation.NewApplication(t)
+	mockApp.EXPECT().BasePath(mock.Anything).RunAndReturn(func(path ...string) string {
+		return filepath.Join(append([]string{dir}, path...)...)
+	})
+	mockApp.EXPECT().ConfigPath(mock.Anything).RunAndReturn(func(path ...string) string {
+		return filepath.Join(append([]string{dir, "config"}, path...)...)
+	})
+
+	return mockApp
+}
+
+func expectStack(mockCtx *mocksconsole.Context, framework string, typescript, tailwind, components bool) {
+	mockCtx.EXPECT().Choice("Which framework do you want to use?", mock.Anything, console.ChoiceOption{Default: "react"}).Return(framework, nil).Once()
+	mockCtx.EXPECT().Confirm("Do you want to use TypeScript?", console.ConfirmOption{Default: true}).Return(typescript, nil).Once()
+	mockCtx.EXPECT().Confirm("Do you want to use Tailwind CSS?", console.ConfirmOption{Default: true}).Return(tailwind, nil).Once()
+	if tailwind {
+		mockCtx.EXPECT().Confirm("Do you want to set up shadcn components?", console.ConfirmOption{Default: true}).Return(components, nil).Once()
+	}
+}
+
+func TestInstallCommand_React(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".env"), []byte("APP_NAME=Goravel\nVITE_JS_FRAMEWORK=vue\n"), 0644))
+
+	mockCtx := mocksconsole.NewContext(t)
+	expectStack(mockCtx, "react", true, true, true)
+	mockCtx.EXPECT().OptionBool("force").Return(false).Once()
+	mockCtx.EXPECT().Info(mock.Anything)
+	mockCtx.EXPECT().Success(mock.Anything).Once()
+
+	require.NoError(t, NewInstallCommand(newInstallTestApp(t, dir)).Handle(mockCtx))
+
+	for _, file := range []string{
+		"config/vite.go",
+		".prettierrc",
+		"resources/views/app.tmpl",
+		"resources/js/App.tsx",
+		"resources/js/main.tsx",
+		"resources/css/app.css",
+		"vite.config.ts",
+		"package.json",
+		"tsconfig.json",
+		"components.json",
+	} {
+		assert.FileExists(t, filepath.Join(dir, file))
+	}
+
+	env, err := os.ReadFile(filepath.Join(dir, ".env"))
+	require.NoError(t, err)
+	assert.Equal(t, "APP_NAME=Goravel\nVITE_JS_FRAMEWORK=react\nVITE_ENTRY_POINTS=resources/js/main.tsx\n", string(env))
+}
+
+func TestInstallCommand_VueWithoutTypeScriptAndTailwind(t *testing.T) {
+	dir := t.TempDir()
+
+	mockCtx := mocksconsole.NewContext(t)
+	expectStack(mockCtx, "vue", false, false, false)
+	mockCtx.EXPECT().OptionBool("force").Return(false).Once()
+	mockCtx.EXPECT().Info(mock.Anything)
+	mockCtx.EXPECT().Success(mock.Anything).Once()
+
+	require.NoError(t, NewInstallCommand(newInstallTestApp(t, dir)).Handle(mockCtx))
+
+	assert.FileExists(t, filepath.Join(dir, "resources/js/main.js"))
+	assert.NoFileExists(t, filepath.Join(dir, "resources/js/main.ts"))
+	assert.NoFileExists(t, filepath.Join(dir, "resources/js/env.d.ts"))
+	assert.NoFileExists(t, filepath.Join(dir, "tsconfig.json"))
+	assert.NoFileExists(t, filepath.Join(dir, "components.json"))
+
+	app, err := os.ReadFile(filepath.Join(dir, "resources/js/App.vue"))
+	require.NoError(t, err)
+	assert.Contains(t, string(app), "<script setup></script>")
+
+	css, err := os.ReadFile(filepath.Join(dir, "resources/css/app.css"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(css), "tailwind")
+
+	viteConfig, err := os.ReadFile(filepath.Join(dir, "vite.config.ts"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(viteConfig), "tailwind")
+	assert.Contains(t, string(viteConfig), "'./resources/js/main.js'")
+
+	for _, file := range []string{"package.json", ".prettierrc"} {
+		content, err := os.ReadFile(filepath.Join(dir, file))
+		require.NoError(t, err)
+		assert.True(t, json.Valid(content), file)
+		assert.NotContains(t, string(content), "tailwind", file)
+	}
+
+	for _, file := range []string{"package.json", "eslint.config.js"} {
+		content, err := os.ReadFile(filepath.Join(dir, file))
+		require.NoError(t, err)
+		assert.NotContains(t, string(content), "typescript", file)
+		assert.NotContains(t, string(content), "vue-tsc", file)
+	}
+
+	eslint, err := os.ReadFile(filepath.Join(dir, "eslint.config.js"))
+	require.NoError(t, err)
+	assert.Contains(t, string(eslint), "export default [\n    ...vue.configs['flat/essential'],")
+	assert.Contains(t, string(eslint), "    prettier,\n];")
+
+	env, err := os.ReadFile(filepath.Join(dir, ".env"))
+	require.NoError(t, err)
+	assert.Equal(t, "VITE_JS_FRAMEWORK=vue\nVITE_ENTRY_POINTS=resources/js/main.js\n", string(env))
+}
+
+func TestInstallCommand_RefusesToOverwrite(t *testing.T) {
+	dir := t.TempDir()
+	packageJSON := filepath.Join(dir, "package.json")
+	require.NoError(t, os.WriteFile(packageJSON, []byte("{}"), 0644))
+
+	mockCtx := mocksconsole.NewContext(t)
+	expectStack(mockCtx, "react", true, true, true)
+	mockCtx.EXPECT().OptionBool("force").Return(false).Once()
+	mockCtx.EXPECT().Error("The following files already exist, use --force to overwrite them:").Once()
+	mockCtx.EXPECT().Line("  " + packageJSON).Once()
+
+	err := NewInstallCommand(newInstallTestApp(t, dir)).Handle(mockCtx)
+	var exitErr *exitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 1, exitErr.ExitCode())
+
+	content, err := os.ReadFile(packageJSON)
+	require.NoError(t, err)
+	assert.Equal(t, "{}", string(content))
+	assert.NoFileExists(t, filepath.Join(dir, "vite.config.ts"))
+
+	mockCtx = mocksconsole.NewContext(t)
+	expectStack(mockCtx, "react", true, true, true)
+	mockCtx.EXPECT().OptionBool("force").Return(true).Once()
+	mockCtx.EXPECT().Info(mock.Anything)
+	mockCtx.EXPECT().Success(mock.Anything).Once()
+
+	require.NoError(t, NewInstallCommand(newInstallTestApp(t, dir)).Handle(mockCtx))
+
+	content, err = os.ReadFile(packageJSON)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `"vite"`)
+}
+
+func TestInstallCommand_ReactWithoutTypeScript(t *testing.T) {
+	dir := t.TempDir()
+
+	mockCtx := mocksconsole.NewContext(t)
+	expectStack(mockCtx, "react", false, true, false)
+	mockCtx.EXPECT().OptionBool("force").Return(false).Once()
+	mockCtx.EXPECT().Info(mock.Anything)
+	mockCtx.EXPECT().Success(mock.Anything).Once()
+
+	require.NoError(t, NewInstallCommand(newInstallTestApp(t, dir)).Handle(mockCtx))
+
+	assert.FileExists(t, filepath.Join(dir, "resources/js/main.jsx"))
+	assert.NoFileExists(t, filepath.Join(dir, "tsconfig.json"))
+
+	packageJSON, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	require.NoError(t, err)
+	assert.True(t, json.Valid(packageJSON))
+	assert.NotContains(t, string(packageJSON), "typescript")
+	assert.NotContains(t, string(packageJSON), "@types/")
+
+	eslint, err := os.ReadFile(filepath.Join(dir, "eslint.config.js"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(eslint), "typescript")
+	assert.Contains(t, string(eslint), "js.configs.recommended,")
+}
+
+func TestInstallCommand_EnvFailure(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, ".env"), 0755))
+
+	mockCtx := mocksconsole.NewContext(t)
+	expectStack(mockCtx, "vue", true, true, true)
+	mockCtx.EXPECT().OptionBool("force").Return(false).Once()
+	mockCtx.EXPECT().Info(mock.Anything)
+
+	err := NewInstallCommand(newInstallTestApp(t, dir)).Handle(mockCtx)
+	var exitErr *exitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 1, exitErr.ExitCode())
+	assert.Contains(t, exitErr.Error(), "failed to update .env")
+}
+
+func TestInstallCommand_PromptFailure(t *testing.T) {
+	mockCtx := mocksconsole.NewContext(t)
+	mockCtx.EXPECT().Choice("Which framework do you want to use?", mock.Anything, console.ChoiceOption{Default: "react"}).Return("", errors.New("no terminal")).Once()
+
+	err := NewInstallCommand(mocksfoundation.NewApplication(t)).Handle(mockCtx)
+	var exitErr *exitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 1, exitErr.ExitCode())
+	assert.Equal(t, "failed to read the stack: no terminal", exitErr.Error())
+}
@@ -0,0 +1,258 @@
+package vite
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"html/template"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/goravel/framework/support/path"
+)
+
+type viteManifest map[string]viteManifestEntry
+type viteManifestEntry struct {
+	File           string   `json:"file"`
+	IsEntry        bool     `json:"isEntry,omitempty"`
+	Src            string   `json:"src,omitempty"`
+	CSS            []string `json:"css,omitempty"`
+	Assets         []string `json:"assets,omitempty"`
+	Imports        []string `json:"imports,omitempty"`
+	DynamicImports []string `json:"dynamicImports,omitempty"`
+
+	Integrity string `json:"integrity,omitempty"`
+}
+
+// viteBuild is a loaded manifest together with the integrity hash and the set
+// of every file it references.
+type viteBuild struct {
+	manifest    viteManifest
+	integrities map[string]string
+	files       map[string]bool
+	stamp       manifestStamp
+	hash        string
+}
+
+// loadManifest returns the cached build, reading the manifest on first use.
+// Failures are not cached, so a manifest written after boot is picked up.
+func (v *Vite) loadManifest() (*viteBuild, error) {
+	v.mu.RLock()
+	b := v.build
+	v.mu.RUnlock()
+
+	if b != nil {
+		return b, nil
+	}
+
+	b, err := v.readManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	v.mu.Lock()
+	if v.build == nil {
+		v.build = b
+	}
+	b = v.build
+	v.mu.Unlock()
+
+	return b, nil
+}
+
+func (v *Vite) readManifest() (*viteBuild, error) {
+	var stamp manifestStamp
+	if v.config.FS == nil {
+		if info, err := os.Stat(path.Base(v.config.ManifestPath)); err == nil {
+			stamp = manifestStamp{modTime: info.ModTime(), size: info.Size()}
+		}
+	}
+
+	data, manifestPath, err := v.readBuildFile(v.config.ManifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest file %q: %w", manifestPath, err)
+	}
+
+	var m viteManifest
+	err = json.Unmarshal(data, &m)
+	if err != nil {
+		return nil, fmt.Errorf("parsing manifest JSON %q: %w", manifestPath, err)
+	}
+
+	files := make(map[string]bool)
+	for _, entry := range m {
+		for _, file := range append(append([]string{entry.File}, entry.CSS...), entry.Assets...) {
+			files[file] = true
+		}
+	}
+
+	ints, err := v.loadIntegrities(m, files)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := md5.Sum(data)
+
+	return &viteBuild{manifest: m, integrities: ints, files: files, stamp: stamp, hash: hex.EncodeToString(sum[:])}, nil
+}
+
+// loadIntegrities collects the Subresource Integrity hash of every script and
+// stylesheet, either from the manifest or by hashing the files under
+// vite.assets_path, or in the assets file system when one is set. A file that
+// cannot be read is logged once and rendered without integrity.
+func (v *Vite) loadIntegrities(m viteManifest, files map[string]bool) (map[string]string, error) {
+	algorithm := strings.ToLower(v.config.Integrity)
+
+	var newHash func() hash.Hash
+	switch algorithm {
+	case "", "false", "none":
+		return nil, nil
+	case "manifest":
+		ints := make(map[string]string)
+		for _, entry := range m {
+			if entry.Integrity != "" {
+				ints[entry.File] = entry.Integrity
+			}
+		}
+		return ints, nil
+	case "sha256":
+		newHash = sha256.New
+	case "sha384":
+		newHash = sha512.New384
+	case "sha512":
+		newHash = sha512.New
+	default:
+		return nil, fmt.Errorf("unsupported integrity algorithm %q", algorithm)
+	}
+
+	assetsPath := path.Base(v.config.AssetsPath)
+	ints := make(map[string]string)
+
+	for file := range files {
+		if !hasTag(file) {
+			continue
+		}
+
+		var data []byte
+		var err error
+		if v.config.FS != nil {
+			data, err = fs.ReadFile(v.config.FS, file)
+		} else {
+			data, err = os.ReadFile(filepath.Join(assetsPath, file))
+		}
+		if err != nil {
+			v.warn("vite: rendering %q without integrity: %v", file, err)
+			continue
+		}
+
+		h := newHash()
+		h.Write(data)
+		ints[file] = algorithm + "-" + base64.StdEncoding.EncodeToString(h.Sum(nil))
+	}
+
+	return ints, nil
+}
+
+// missingFiles returns, sorted, the files referenced by the build that cannot
+// be found in its output.
+func (v *Vite) missingFiles(build *viteBuild) []string {
+	var missing []string
+	for file := range build.files {
+		if _, err := v.statBuildFile(file); err != nil {
+			missing = append(missing, file)
+		}
+	}
+	sort.Strings(missing)
+
+	return missing
+}
+
+// hasTag reports whether file is loaded by a tag that can carry an integrity
+// attribute: a JavaScript chunk or a stylesheet.
+func hasTag(file string) bool {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".js", ".mjs", ".css":
+		return true
+	}
+
+	return false
+}
+
+// attributes returns the integrity and crossorigin attributes for a tag
+// loading file. Integrity checks need a CORS request, so crossorigin defaults
+// to "anonymous" when the file has an integrity hash.
+func (b *viteBuild) attributes(file, crossOrigin string) string {
+	var attrs string
+
+	if integrity, ok := b.integrities[file]; ok {
+		attrs = fmt.Sprintf(` integrity="%s"`, template.HTMLEscapeString(integrity))
+		if crossOrigin == "" {
+			crossOrigin = "anonymous"
+		}
+	}
+
+	if crossOrigin != "" {
+		attrs += fmt.Sprintf(` crossorigin="%s"`, template.HTMLEscapeString(crossOrigin))
+	}
+
+	return attrs
+}
+
+// preload is a file the browser should fetch before it reaches the tag that
+// uses it: a JavaScript chunk loaded as a module, or a stylesheet.
+type preload struct {
+	file  string
+	style bool
+}
+
+// preloads walks the import graph of the given entries and returns every
+// chunk and stylesheet they need, in document order and without duplicates.
+func (b *viteBuild) preloads(entries []string) []preload {
+	var preloads []preload
+	includedJS := make(map[string]bool)
+	includedCSS := make(map[string]bool)
+
+	var walk func(string)
+	walk = func(moduleSrc string) {
+		if includedJS[moduleSrc] {
+			return
+		}
+		entry, ok := b.manifest[moduleSrc]
+		if !ok {
+			return
+		}
+
+		preloads = append(preloads, preload{file: entry.File})
+		includedJS[moduleSrc] = true
+
+		for _, imp := range entry.Imports {
+			walk(imp)
+		}
+	}
+
+	for _, entrySrc := range entries {
+		entry, ok := b.manifest[entrySrc]
+		if !ok {
+			continue
+		}
+
+		walk(entrySrc)
+
+		for _, cssFile := range entry.CSS {
+			if !includedCSS[cssFile] {
+				preloads = append(preloads, preload{file: cssFile, style: true})
+				includedCSS[cssFile] = true
+			}
+		}
+	}
+
+	return preloads
+}
@@ -0,0 +1,257 @@
+package vite
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/contracts/foundation"
+)
+
+// ManifestCommand prints the entries of the manifest with the chunks and
+// stylesheets each of them loads.
+type ManifestCommand struct {
+	app foundation.Application
+}
+
+func NewManifestCommand(app foundation.Application) *ManifestCommand {
+	return &ManifestCommand{app: app}
+}
+
+// Signature The name and signature of the console command.
+func (receiver *ManifestCommand) Signature() string {
+	return "vite:manifest"
+}
+
+// Description The console command description.
+func (receiver *ManifestCommand) Description() string {
+	return "Show the import tree of the Vite manifest entries"
+}
+
+// Extend The console command extend.
+func (receiver *ManifestCommand) Extend() command.Extend {
+	return command.Extend{
+		Category:  "vite",
+		ArgsUsage: "[entry...]",
+		Flags: []command.Flag{
+			&command.BoolFlag{
+				Name:  "json",
+				Usage: "Output the report as JSON",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (receiver *ManifestCommand) Handle(ctx console.Context) error {
+	instance, err := receiver.app.Make(Binding)
+	if err != nil {
+		return &exitError{message: err.Error(), code: 1}
+	}
+
+	report, err := instance.(*Vite).manifestReport(ctx.Arguments())
+	if err != nil {
+		return &exitError{message: err.Error(), code: 1}
+	}
+
+	if ctx.OptionBool("json") {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		ctx.Line(string(data))
+		return nil
+	}
+
+	for _, entry := range report.Entries {
+		ctx.Line(entry.label())
+		printChunkTree(ctx, entry, "")
+		ctx.NewLine()
+	}
+
+	if len(report.Orphans) > 0 {
+		ctx.Warning("Chunks not reachable from any entry:")
+		for _, orphan := range report.Orphans {
+			ctx.Line("  " + orphan)
+		}
+	}
+
+	if len(report.Missing) > 0 {
+		ctx.Error("Files missing from the assets path:")
+		for _, file := range report.Missing {
+			ctx.Line("  " + file)
+		}
+	}
+
+	return nil
+}
+
+type manifestReport struct {
+	Entries []*chunkNode `json:"entries"`
+	Orphans []string     `json:"orphans"`
+	Missing []string     `json:"missing"`
+}
+
+// chunkNode is a manifest chunk with its stylesheets and static imports
+// resolved. Dynamic imports are listed by key only, since they are loaded
+// on demand and may import the chunk back.
+type chunkNode struct {
+	Src            string       `json:"src"`
+	File           string       `json:"file,omitempty"`
+	Size           int64        `json:"size"`
+	Missing        bool         `json:"missing,omitempty"`
+	CSS            []*fileNode  `json:"css,omitempty"`
+	Imports        []*chunkNode `json:"imports,omitempty"`
+	DynamicImports []string     `json:"dynamic_imports,omitempty"`
+}
+
+type fileNode struct {
+	File    string `json:"file"`
+	Size    int64  `json:"size"`
+	Missing bool   `json:"missing,omitempty"`
+}
+
+// manifestReport reads the manifest from disk and resolves the given entries,
+// or every entry of the manifest when none are given.
+func (v *Vite) manifestReport(entries []string) (*manifestReport, error) {
+	build, err := v.readManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entries) == 0 {
+		for key, entry := range build.manifest {
+			if entry.IsEntry {
+				entries = append(entries, key)
+			}
+		}
+		sort.Strings(entries)
+	}
+
+	stat := func(file string) (int64, bool) {
+		info, err := v.statBuildFile(file)
+		if err != nil {
+			return 0, true
+		}
+		return info.Size(), false
+	}
+
+	var resolve func(key string, visiting map[string]bool) *chunkNode
+	resolve = func(key string, visiting map[string]bool) *chunkNode {
+		node := &chunkNode{Src: key}
+		entry, ok := build.manifest[key]
+		if !ok || visiting[key] {
+			node.Missing = !ok
+			return node
+		}
+		visiting[key] = true
+		defer delete(visiting, key)
+
+		node.File = entry.File
+		node.Size, node.Missing = stat(entry.File)
+		for _, css := range entry.CSS {
+			size, missing := stat(css)
+			node.CSS = append(node.CSS, &fileNode{File: css, Size: size, Missing: missing})
+		}
+		for _, imp := range entry.Imports {
+			node.Imports = append(node.Imports, resolve(imp, visiting))
+		}
+		node.DynamicImports = entry.DynamicImports
+
+		return node
+	}
+
+	report := &manifestReport{Orphans: []string{}, Missing: []string{}}
+	for _, entry := range entries {
+		report.Entries = append(report.Entries, resolve(entry, make(map[string]bool)))
+	}
+
+	// A chunk is reachable through static or dynamic imports from any entry of
+	// the manifest, not only the ones being reported.
+	reachable := make(map[string]bool)
+	var walk func(string)
+	walk = func(key string) {
+		if reachable[key] {
+			return
+		}
+		reachable[key] = true
+		for _, imp := range build.manifest[key].Imports {
+			walk(imp)
+		}
+		for _, imp := range build.manifest[key].DynamicImports {
+			walk(imp)
+		}
+	}
+	for key, entry := range build.manifest {
+		if entry.IsEntry {
+			walk(key)
+		}
+	}
+
+	for key, entry := range build.manifest {
+		ext := strings.ToLower(filepath.Ext(entry.File))
+		if !reachable[key] && (ext == ".js" || ext == ".mjs") {
+			report.Orphans = append(report.Orphans, key)
+		}
+	}
+	sort.Strings(report.Orphans)
+
+	report.Missing = append(report.Missing, v.missingFiles(build)...)
+
+	return report, nil
+}
+
+func (n *chunkNode) label() string {
+	if n.File == "" {
+		if n.Missing {
+			return n.Src + " (not in manifest)"
+		}
+		return n.Src + " (circular)"
+	}
+
+	return fmt.Sprintf("%s → %s (%s)", n.Src, n.File, sizeLabel(n.Size, n.Missing))
+}
+
+func printChunkTree(ctx console.Context, node *chunkNode, indent string) {
+	var lines []string
+	var children []*chunkNode
+	for _, css := range node.CSS {
+		lines = append(lines, fmt.Sprintf("%s (%s)", css.File, sizeLabel(css.Size, css.Missing)))
+		children = append(children, nil)
+	}
+	for _, imp := range node.Imports {
+		lines = append(lines, imp.label())
+		children = append(children, imp)
+	}
+	for _, imp := range node.DynamicImports {
+		lines = append(lines, imp+" (dynamic)")
+		children = append(children, nil)
+	}
+
+	for i, line := range lines {
+		branch, next := "├── ", "│   "
+		if i == len(lines)-1 {
+			branch, next = "└── ", "    "
+		}
+
+		ctx.Line(indent + branch + line)
+		if children[i] != nil {
+			printChunkTree(ctx, children[i], indent+next)
+		}
+	}
+}
+
+func sizeLabel(size int64, missing bool) string {
+	if missing {
+		return "missing"
+	}
+	if size < 1024 {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	return fmt.Sprintf("%.1f kB", float64(size)/1024)
+}
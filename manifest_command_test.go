@@ -0,0 +1,153 @@
+package vite
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	mocksconsole "github.com/goravel/framework/mocks/console"
+	mocksfoundation "github.com/goravel/framework/mocks/foundation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const manifestCommandTestManifest = `{
+	"resources/js/main.ts": {
+		"file": "assets/main.js",
+		"isEntry": true,
+		"imports": ["_vendor.js"],
+		"dynamicImports": ["resources/js/pages/Dashboard.vue"],
+		"css": ["assets/main.css"]
+	},
+	"_vendor.js": {
+		"file": "assets/vendor.js"
+	},
+	"resources/js/pages/Dashboard.vue": {
+		"file": "assets/Dashboard.js",
+		"imports": ["_vendor.js"]
+	},
+	"_unused.js": {
+		"file": "assets/unused.js"
+	}
+}`
+
+func writeManifestCommandFixture(t *testing.T) *Vite {
+	assetsPath := t.TempDir()
+	manifestPath := filepath.Join(assetsPath, "manifest.json")
+	require.NoError(t, os.WriteFile(manifestPath, []byte(manifestCommandTestManifest), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(assetsPath, "assets"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(assetsPath, "assets", "main.js"), []byte(strings.Repeat("a", 2048)), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(assetsPath, "assets", "vendor.js"), []byte("vendor"), 0644))
+
+	return NewViteWithConfig(testConfig(assetsPath))
+}
+
+func TestManifestReport(t *testing.T) {
+	report, err := writeManifestCommandFixture(t).manifestReport(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, &manifestReport{
+		Entries: []*chunkNode{
+			{
+				Src:  "resources/js/main.ts",
+				File: "assets/main.js",
+				Size: 2048,
+				CSS:  []*fileNode{{File: "assets/main.css", Missing: true}},
+				Imports: []*chunkNode{
+					{Src: "_vendor.js", File: "assets/vendor.js", Size: 6},
+				},
+				DynamicImports: []string{"resources/js/pages/Dashboard.vue"},
+			},
+		},
+		Orphans: []string{"_unused.js"},
+		Missing: []string{"assets/Dashboard.js", "assets/main.css", "assets/unused.js"},
+	}, report)
+}
+
+func TestManifestReport_FS(t *testing.T) {
+	config := testConfig(t.TempDir())
+	config.AssetsPath = "missing"
+	config.ManifestPath = "missing/manifest.json"
+	config.FS = fstest.MapFS{
+		"manifest.json":   {Data: []byte(`{"resources/js/main.ts": {"file": "assets/main.js", "isEntry": true, "css": ["assets/main.css"]}}`)},
+		"assets/main.js":  {Data: []byte(strings.Repeat("a", 2048))},
+		"assets/main.css": {Data: []byte("body{}")},
+	}
+
+	report, err := NewViteWithConfig(config).manifestReport(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, &manifestReport{
+		Entries: []*chunkNode{
+			{
+				Src:  "resources/js/main.ts",
+				File: "assets/main.js",
+				Size: 2048,
+				CSS:  []*fileNode{{File: "assets/main.css", Size: 6}},
+			},
+		},
+		Orphans: []string{},
+		Missing: []string{},
+	}, report)
+}
+
+func TestManifestCommand(t *testing.T) {
+	mockApp := mocksfoundation.NewApplication(t)
+	mockApp.EXPECT().Make(Binding).Return(writeManifestCommandFixture(t), nil).Once()
+
+	var lines []string
+	mockCtx := mocksconsole.NewContext(t)
+	mockCtx.EXPECT().Arguments().Return([]string{"resources/js/main.ts", "resources/js/admin.ts"}).Once()
+	mockCtx.EXPECT().OptionBool("json").Return(false).Once()
+	mockCtx.EXPECT().Line(mock.Anything).Run(func(message string) {
+		lines = append(lines, message)
+	})
+	mockCtx.EXPECT().NewLine().Twice()
+	mockCtx.EXPECT().Warning("Chunks not reachable from any entry:").Once()
+	mockCtx.EXPECT().Error("Files missing from the assets path:").Once()
+
+	require.NoError(t, NewManifestCommand(mockApp).Handle(mockCtx))
+
+	assert.Equal(t, []string{
+		"resources/js/main.ts → assets/main.js (2.0 kB)",
+		"├── assets/main.css (missing)",
+		"├── _vendor.js → assets/vendor.js (6 B)",
+		"└── resources/js/pages/Dashboard.vue (dynamic)",
+		"resources/js/admin.ts (not in manifest)",
+		"  _unused.js",
+		"  assets/Dashboard.js",
+		"  assets/main.css",
+		"  assets/unused.js",
+	}, lines)
+}
+
+func TestManifestCommand_JSON(t *testing.T) {
+	mockApp := mocksfoundation.NewApplication(t)
+	mockApp.EXPECT().Make(Binding).Return(writeManifestCommandFixture(t), nil).Once()
+
+	mockCtx := mocksconsole.NewContext(t)
+	mockCtx.EXPECT().Arguments().Return([]string{"_vendor.js"}).Once()
+	mockCtx.EXPECT().OptionBool("json").Return(true).Once()
+	mockCtx.EXPECT().Line(`{
+  "entries": [
+    {
+      "src": "_vendor.js",
+      "file": "assets/vendor.js",
+      "size": 6
+    }
+  ],
+  "orphans": [
+    "_unused.js"
+  ],
+  "missing": [
+    "assets/Dashboard.js",
+    "assets/main.css",
+    "assets/unused.js"
+  ]
+}`).Once()
+
+	require.NoError(t, NewManifestCommand(mockApp).Handle(mockCtx))
+}
@@ -0,0 +1,95 @@
+package vite
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"net/url"
+	"strings"
+
+	"github.com/goravel/framework/contracts/http"
+)
+
+const defaultContentSecurityPolicy = "default-src 'self'; script-src 'self' {nonce} {dev_server}; style-src 'self' 'unsafe-inline' {dev_server}; img-src 'self' data: {dev_server}; font-src 'self' data: {dev_server}; connect-src 'self' {dev_server}"
+
+type nonceContextKey struct{}
+
+// CSPNonce returns the nonce generated by the ContentSecurityPolicy middleware
+// for the current request, or an empty string when the middleware did not run
+// or ctx is nil.
+func CSPNonce(ctx http.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	nonce, _ := ctx.Value(nonceContextKey{}).(string)
+	return nonce
+}
+
+// ContentSecurityPolicy generates a nonce for every request, stores it on the
+// context for AssetsWithNonce and sets the Content-Security-Policy header
+// from vite.csp.
+func ContentSecurityPolicy() http.Middleware {
+	return func(ctx http.Context) {
+		nonce, err := generateNonce()
+		if err != nil {
+			ctx.Request().AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.WithValue(nonceContextKey{}, nonce)
+
+		if v, err := resolve(); err == nil {
+			ctx.Response().Header("Content-Security-Policy", v.contentSecurityPolicy(nonce))
+		}
+
+		ctx.Request().Next()
+	}
+}
+
+func (v *Vite) contentSecurityPolicy(nonce string) string {
+	policy := v.config.CSP
+
+	devServer := ""
+	if viteDevServer, hot := v.hotServer(); hot {
+		devServer = devServerOrigins(viteDevServer)
+	}
+
+	policy = strings.NewReplacer(
+		"{nonce}", "'nonce-"+nonce+"'",
+		"{dev_server}", devServer,
+	).Replace(policy)
+
+	var directives []string
+	for _, directive := range strings.Split(policy, ";") {
+		if fields := strings.Fields(directive); len(fields) > 0 {
+			directives = append(directives, strings.Join(fields, " "))
+		}
+	}
+
+	return strings.Join(directives, "; ")
+}
+
+// devServerOrigins returns the HTTP origin of the dev server together with the
+// matching WebSocket origin used by Vite's HMR client.
+func devServerOrigins(viteDevServer string) string {
+	u, err := url.Parse(viteDevServer)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+
+	ws := "ws"
+	if u.Scheme == "https" {
+		ws = "wss"
+	}
+
+	return u.Scheme + "://" + u.Host + " " + ws + "://" + u.Host
+}
+
+func generateNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(b), nil
+}
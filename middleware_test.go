@@ -0,0 +1,116 @@
+package vite
+
+import (
+	"html/template"
+	"os"
+	"strings"
+	"testing"
+
+	mocksfoundation "github.com/goravel/framework/mocks/foundation"
+	mockshttp "github.com/goravel/framework/mocks/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentSecurityPolicy(t *testing.T) {
+	config := testConfig(t.TempDir())
+	config.CSP = "script-src 'self' {nonce} {dev_server};  connect-src 'self' {dev_server}"
+	require.NoError(t, os.WriteFile(config.HotFile, []byte("http://localhost:5173"), 0644))
+
+	mockApp := mocksfoundation.NewApplication(t)
+	mockCtx := mockshttp.NewContext(t)
+	mockRequest := mockshttp.NewContextRequest(t)
+	mockResponse := mockshttp.NewContextResponse(t)
+
+	originalApp := App
+	defer func() {
+		App = originalApp
+	}()
+	App = mockApp
+
+	mockApp.EXPECT().Make(Binding).Return(NewViteWithConfig(config), nil).Once()
+
+	var nonce string
+	mockCtx.EXPECT().WithValue(nonceContextKey{}, mock.AnythingOfType("string")).Run(func(key any, value any) {
+		nonce = value.(string)
+	}).Once()
+	mockCtx.EXPECT().Response().Return(mockResponse).Once()
+	mockResponse.EXPECT().Header("Content-Security-Policy", mock.AnythingOfType("string")).Run(func(key, value string) {
+		assert.Equal(t, "script-src 'self' 'nonce-"+nonce+"' http://localhost:5173 ws://localhost:5173; connect-src 'self' http://localhost:5173 ws://localhost:5173", value)
+	}).Return(mockResponse).Once()
+	mockCtx.EXPECT().Request().Return(mockRequest).Once()
+	mockRequest.EXPECT().Next().Once()
+
+	ContentSecurityPolicy()(mockCtx)
+
+	assert.Len(t, nonce, 24)
+}
+
+func TestContentSecurityPolicy_WithoutDevServer(t *testing.T) {
+	policy := NewViteWithConfig(testConfig(t.TempDir())).contentSecurityPolicy("abc")
+
+	assert.Equal(t, "default-src 'self'; script-src 'self' 'nonce-abc'; style-src 'self' 'unsafe-inline'; img-src 'self' data:; font-src 'self' data:; connect-src 'self'", policy)
+}
+
+func TestCSPNonce(t *testing.T) {
+	mockCtx := mockshttp.NewContext(t)
+	mockCtx.EXPECT().Value(nonceContextKey{}).Return("abc").Once()
+	assert.Equal(t, "abc", CSPNonce(mockCtx))
+
+	mockCtx.EXPECT().Value(nonceContextKey{}).Return(nil).Once()
+	assert.Equal(t, "", CSPNonce(mockCtx))
+}
+
+func TestContentSecurityPolicy_PublishedTemplates(t *testing.T) {
+	config := testConfig(t.TempDir())
+	config.JSFramework = "react"
+	require.NoError(t, os.WriteFile(config.HotFile, []byte("http://localhost:5173"), 0644))
+	vite := NewViteWithConfig(config)
+
+	mockApp := mocksfoundation.NewApplication(t)
+	mockCtx := mockshttp.NewContext(t)
+	mockRequest := mockshttp.NewContextRequest(t)
+	mockResponse := mockshttp.NewContextResponse(t)
+
+	originalApp := App
+	defer func() {
+		App = originalApp
+	}()
+	App = mockApp
+
+	mockApp.EXPECT().Make(Binding).Return(vite, nil).Once()
+
+	var nonce string
+	mockCtx.EXPECT().WithValue(nonceContextKey{}, mock.AnythingOfType("string")).Run(func(key any, value any) {
+		nonce = value.(string)
+	}).Once()
+	mockCtx.EXPECT().Response().Return(mockResponse).Once()
+	mockResponse.EXPECT().Header("Content-Security-Policy", mock.AnythingOfType("string")).Return(mockResponse).Once()
+	mockCtx.EXPECT().Request().Return(mockRequest).Once()
+	mockRequest.EXPECT().Next().Once()
+
+	ContentSecurityPolicy()(mockCtx)
+	mockCtx.EXPECT().Value(nonceContextKey{}).RunAndReturn(func(any) any {
+		return nonce
+	})
+
+	for _, file := range []string{
+		"templates/react/views/app.tmpl",
+		"templates/vue/views/app.tmpl",
+		"templates/inertia/react/views/app.tmpl",
+		"templates/inertia/vue/views/app.tmpl",
+	} {
+		t.Run(file, func(t *testing.T) {
+			tmpl, err := template.ParseFiles(file)
+			require.NoError(t, err)
+
+			var sb strings.Builder
+			require.NoError(t, tmpl.ExecuteTemplate(&sb, "app.tmpl", map[string]any{"vite": vite, "ctx": mockCtx}))
+
+			html := sb.String()
+			assert.Equal(t, 4, strings.Count(html, "<script"), "appearance script, React Refresh preamble, Vite client and entry")
+			assert.Equal(t, strings.Count(html, "<script"), strings.Count(html, `nonce="`+template.HTMLEscapeString(nonce)+`"`))
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package vite
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// prefetches returns the chunks and stylesheets reachable from the given
+// entries only through dynamic imports, leaving out everything the entries
+// already preload.
+func (b *viteBuild) prefetches(entries []string) []preload {
+	included := make(map[string]bool)
+	for _, p := range b.preloads(entries) {
+		included[p.file] = true
+	}
+
+	var prefetches []preload
+	visitedStatic := make(map[string]bool)
+	visitedDynamic := make(map[string]bool)
+
+	var walk func(string, bool)
+	walk = func(moduleSrc string, dynamic bool) {
+		visited := visitedStatic
+		if dynamic {
+			visited = visitedDynamic
+		}
+		if visited[moduleSrc] {
+			return
+		}
+		visited[moduleSrc] = true
+
+		entry, ok := b.manifest[moduleSrc]
+		if !ok {
+			return
+		}
+
+		if dynamic {
+			if !included[entry.File] {
+				prefetches = append(prefetches, preload{file: entry.File})
+				included[entry.File] = true
+			}
+
+			for _, cssFile := range entry.CSS {
+				if !included[cssFile] {
+					prefetches = append(prefetches, preload{file: cssFile, style: true})
+					included[cssFile] = true
+				}
+			}
+		}
+
+		for _, imp := range entry.Imports {
+			walk(imp, dynamic)
+		}
+		for _, imp := range entry.DynamicImports {
+			walk(imp, true)
+		}
+	}
+
+	for _, entrySrc := range entries {
+		walk(entrySrc, false)
+	}
+
+	return prefetches
+}
+
+// prefetchScript renders the inline script that prefetches the lazily loaded
+// chunks of the given entries once the page has loaded. The "waterfall"
+// strategy keeps vite.prefetch.concurrency requests in flight, "aggressive"
+// requests everything at once, and any other strategy disables prefetching.
+func (v *Vite) prefetchScript(build *viteBuild, entries []string, baseURL, crossOrigin, nonceAttr string) string {
+	prefetches := build.prefetches(entries)
+	if len(prefetches) == 0 {
+		return ""
+	}
+
+	var concurrency int
+	switch strings.ToLower(v.config.Prefetch.Strategy) {
+	case "waterfall":
+		concurrency = max(v.config.Prefetch.Concurrency, 1)
+	case "aggressive":
+		concurrency = len(prefetches)
+	default:
+		return ""
+	}
+
+	assets := make([]map[string]string, 0, len(prefetches))
+
+	for _, p := range prefetches {
+		asset := map[string]string{
+			"rel":           "prefetch",
+			"href":          baseURL + p.file,
+			"fetchpriority": "low",
+		}
+		if p.style {
+			asset["as"] = "style"
+		}
+		if crossOrigin != "" {
+			asset["crossorigin"] = crossOrigin
+		}
+		if integrity, ok := build.integrities[p.file]; ok {
+			asset["integrity"] = integrity
+			if crossOrigin == "" {
+				asset["crossorigin"] = "anonymous"
+			}
+		}
+
+		assets = append(assets, asset)
+	}
+
+	// json.Marshal escapes <, > and &, so the list cannot close the script.
+	data, err := json.Marshal(assets)
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf(`<script%s>
+			window.addEventListener("load", () => window.setTimeout(() => {
+				const assets = %s;
+				const next = () => {
+					const asset = assets.shift();
+					if (!asset) return;
+					const link = document.createElement("link");
+					Object.entries(asset).forEach(([name, value]) => link.setAttribute(name, value));
+					link.onload = link.onerror = next;
+					document.head.append(link);
+				};
+				for (let i = 0; i < %d; i++) next();
+			}));
+			</script>`, nonceAttr, data, concurrency)
+}
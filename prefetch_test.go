@@ -0,0 +1,94 @@
+package vite
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const prefetchTestManifest = `{
+	"resources/js/app.js": {
+		"file": "assets/app.js",
+		"isEntry": true,
+		"imports": ["_vendor.js"],
+		"dynamicImports": ["resources/js/pages/Dashboard.vue"],
+		"css": ["assets/app.css"]
+	},
+	"_vendor.js": {
+		"file": "assets/vendor.js"
+	},
+	"resources/js/pages/Dashboard.vue": {
+		"file": "assets/Dashboard.js",
+		"imports": ["_vendor.js", "_chart.js"],
+		"dynamicImports": ["resources/js/pages/Settings.vue"],
+		"css": ["assets/Dashboard.css"]
+	},
+	"_chart.js": {
+		"file": "assets/chart.js"
+	},
+	"resources/js/pages/Settings.vue": {
+		"file": "assets/Settings.js",
+		"dynamicImports": ["resources/js/pages/Dashboard.vue"]
+	}
+}`
+
+func TestPrefetches(t *testing.T) {
+	var m viteManifest
+	require.NoError(t, json.Unmarshal([]byte(prefetchTestManifest), &m))
+
+	prefetches := (&viteBuild{manifest: m}).prefetches([]string{"resources/js/app.js"})
+
+	assert.Equal(t, []preload{
+		{file: "assets/Dashboard.js"},
+		{file: "assets/Dashboard.css", style: true},
+		{file: "assets/chart.js"},
+		{file: "assets/Settings.js"},
+	}, prefetches)
+}
+
+func TestAssets_PrefetchStrategies(t *testing.T) {
+	tests := []struct {
+		strategy    string
+		concurrency int
+		contains    []string
+	}{
+		{
+			strategy:    "waterfall",
+			concurrency: 2,
+			contains: []string{
+				`<script nonce="abc">`,
+				`const assets = [{"fetchpriority":"low","href":"/static/assets/Dashboard.js","rel":"prefetch"},{"as":"style","fetchpriority":"low","href":"/static/assets/Dashboard.css","rel":"prefetch"},{"fetchpriority":"low","href":"/static/assets/chart.js","rel":"prefetch"},{"fetchpriority":"low","href":"/static/assets/Settings.js","rel":"prefetch"}];`,
+				`for (let i = 0; i < 2; i++) next();`,
+			},
+		},
+		{
+			strategy: "aggressive",
+			contains: []string{
+				`for (let i = 0; i < 4; i++) next();`,
+			},
+		},
+		{
+			strategy: "none",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.strategy, func(t *testing.T) {
+			config := testConfig(t.TempDir())
+			config.Prefetch = PrefetchConfig{Strategy: test.strategy, Concurrency: test.concurrency}
+			require.NoError(t, os.WriteFile(config.ManifestPath, []byte(prefetchTestManifest), 0644))
+
+			html := string(NewViteWithConfig(config).render("abc", []string{"resources/js/app.js"}))
+
+			if len(test.contains) == 0 {
+				assert.NotContains(t, html, "prefetch")
+			}
+			for _, expected := range test.contains {
+				assert.Contains(t, html, expected)
+			}
+		})
+	}
+}
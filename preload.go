@@ -0,0 +1,90 @@
+package vite
+
+import (
+	"fmt"
+	nethttp "net/http"
+	"strings"
+
+	"github.com/goravel/framework/contracts/http"
+)
+
+// PreloadHeaders sends the chunks and stylesheets of the given entries as
+// Link headers, so the browser starts fetching them before the HTML arrives.
+// When no entries are given, the configured vite.entry_points are used. With
+// vite.preload.early_hints enabled the same links are also sent in a 103
+// Early Hints response before the handler runs.
+func PreloadHeaders(entries ...string) http.Middleware {
+	return func(ctx http.Context) {
+		if v, err := resolve(); err == nil {
+			if link := v.linkHeader(entries); link != "" {
+				ctx.Response().Header("Link", link)
+
+				if v.config.Preload.EarlyHints {
+					writeEarlyHints(ctx.Response().Writer(), link)
+				}
+			}
+		}
+
+		ctx.Request().Next()
+	}
+}
+
+// linkHeader returns the Link header value preloading the given entries, capped
+// at vite.preload.max_hints links. Nothing is preloaded while the dev server is
+// running or when the manifest cannot be loaded.
+func (v *Vite) linkHeader(entries []string) string {
+	if _, hot := v.hotServer(); hot {
+		return ""
+	}
+
+	entries = v.resolveEntries(entries)
+
+	build, err := v.loadManifest()
+	if err != nil {
+		return ""
+	}
+
+	preloads := build.preloads(entries)
+	if maxHints := v.config.Preload.MaxHints; maxHints > 0 && len(preloads) > maxHints {
+		preloads = preloads[:maxHints]
+	}
+
+	baseURL, crossOrigin := v.assetURL()
+	links := make([]string, 0, len(preloads))
+
+	for _, p := range preloads {
+		link := fmt.Sprintf("<%s>; rel=modulepreload", baseURL+p.file)
+		if p.style {
+			link = fmt.Sprintf("<%s>; rel=preload; as=style", baseURL+p.file)
+		}
+
+		// The preload is only reused when its CORS mode matches the tag's.
+		mode := crossOrigin
+		if _, ok := build.integrities[p.file]; ok && mode == "" {
+			mode = "anonymous"
+		}
+		if mode != "" {
+			link += "; crossorigin=" + mode
+		}
+
+		links = append(links, link)
+	}
+
+	return strings.Join(links, ", ")
+}
+
+// writeEarlyHints sends a 103 response carrying the Link header. Drivers that
+// buffer the status code, like gin, wrap the net/http writer, so the informational
+// response is written to the innermost writer that supports it.
+func writeEarlyHints(w nethttp.ResponseWriter, link string) {
+	for {
+		wrapper, ok := w.(interface{ Unwrap() nethttp.ResponseWriter })
+		if !ok {
+			break
+		}
+		w = wrapper.Unwrap()
+	}
+
+	w.Header().Set("Link", link)
+	w.WriteHeader(nethttp.StatusEarlyHints)
+}
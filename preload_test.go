@@ -0,0 +1,105 @@
+package vite
+
+import (
+	nethttp "net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	mocksfoundation "github.com/goravel/framework/mocks/foundation"
+	mockshttp "github.com/goravel/framework/mocks/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const preloadTestManifest = `{
+	"resources/js/app.js": {
+		"file": "assets/app.js",
+		"isEntry": true,
+		"imports": ["_vendor.js"],
+		"css": ["assets/app.css"]
+	},
+	"_vendor.js": {
+		"file": "assets/vendor.js",
+		"imports": ["_shared.js"]
+	},
+	"_shared.js": {
+		"file": "assets/shared.js"
+	}
+}`
+
+// bufferingWriter stands in for drivers that record the status code instead
+// of writing it, so informational responses must bypass it.
+type bufferingWriter struct {
+	nethttp.ResponseWriter
+	status int
+}
+
+func (w *bufferingWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+func (w *bufferingWriter) Unwrap() nethttp.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func TestPreloadHeaders(t *testing.T) {
+	config := testConfig(t.TempDir())
+	config.Preload = PreloadConfig{MaxHints: 3, EarlyHints: true}
+	require.NoError(t, os.WriteFile(config.ManifestPath, []byte(preloadTestManifest), 0644))
+
+	mockApp := mocksfoundation.NewApplication(t)
+	mockCtx := mockshttp.NewContext(t)
+	mockRequest := mockshttp.NewContextRequest(t)
+	mockResponse := mockshttp.NewContextResponse(t)
+
+	originalApp := App
+	defer func() {
+		App = originalApp
+	}()
+	App = mockApp
+
+	mockApp.EXPECT().Make(Binding).Return(NewViteWithConfig(config), nil).Once()
+
+	expected := "</static/assets/app.js>; rel=modulepreload, </static/assets/vendor.js>; rel=modulepreload, </static/assets/shared.js>; rel=modulepreload"
+	recorder := httptest.NewRecorder()
+	writer := &bufferingWriter{ResponseWriter: recorder}
+
+	mockCtx.EXPECT().Response().Return(mockResponse).Twice()
+	mockResponse.EXPECT().Header("Link", expected).Return(mockResponse).Once()
+	mockResponse.EXPECT().Writer().Return(writer).Once()
+	mockCtx.EXPECT().Request().Return(mockRequest).Once()
+	mockRequest.EXPECT().Next().Once()
+
+	PreloadHeaders("resources/js/app.js")(mockCtx)
+
+	assert.Equal(t, nethttp.StatusEarlyHints, recorder.Code)
+	assert.Equal(t, expected, recorder.Header().Get("Link"))
+	assert.Zero(t, writer.status)
+}
+
+func TestLinkHeader_Integrity(t *testing.T) {
+	config := testConfig(t.TempDir())
+	config.EntryPoints = []string{"resources/js/app.js"}
+	config.Integrity = "manifest"
+	config.Preload.MaxHints = 0
+	require.NoError(t, os.WriteFile(config.ManifestPath, []byte(`{
+		"resources/js/app.js": {
+			"file": "assets/app.js",
+			"isEntry": true,
+			"css": ["assets/app.css"],
+			"integrity": "sha384-abc"
+		}
+	}`), 0644))
+
+	link := NewViteWithConfig(config).linkHeader(nil)
+
+	assert.Equal(t, "</static/assets/app.js>; rel=modulepreload; crossorigin=anonymous, </static/assets/app.css>; rel=preload; as=style", link)
+}
+
+func TestLinkHeader_DevServer(t *testing.T) {
+	config := testConfig(t.TempDir())
+	require.NoError(t, os.WriteFile(config.HotFile, []byte("http://localhost:5173"), 0644))
+
+	assert.Empty(t, NewViteWithConfig(config).linkHeader([]string{"resources/js/app.js"}))
+}
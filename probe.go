@@ -0,0 +1,89 @@
+package vite
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	nethttp "net/http"
+	"strings"
+	"time"
+)
+
+// devServerProbe is the cached result of the last request to the dev server.
+type devServerProbe struct {
+	url       string
+	reachable bool
+	checkedAt time.Time
+}
+
+// devServer reads the hot file and probes the server it names. hot reports
+// whether the dev server assets should be used. When the server does not
+// answer, vite.probe.fallback decides between the build ("build"), reported
+// as not hot, and an error banner ("banner"), reported as hot and down.
+func (v *Vite) devServer() (url string, hot bool, down bool) {
+	url, hot = v.hotFile()
+	if !hot || v.devServerReachable(url) {
+		return url, hot, false
+	}
+
+	if strings.ToLower(v.config.Probe.Fallback) == "banner" {
+		return url, true, true
+	}
+
+	return "", false, false
+}
+
+// devServerReachable sends a HEAD request for the Vite client, reusing the
+// previous answer for vite.probe.interval milliseconds. Any HTTP response
+// counts as reachable; only connection failures and timeouts do not.
+func (v *Vite) devServerReachable(viteDevServer string) bool {
+	if !v.config.Probe.Enabled {
+		return true
+	}
+
+	v.mu.RLock()
+	probe := v.probe
+	v.mu.RUnlock()
+
+	if probe != nil && probe.url == viteDevServer && time.Since(probe.checkedAt) < v.config.Probe.Interval {
+		return probe.reachable
+	}
+
+	probe = &devServerProbe{
+		url:       viteDevServer,
+		reachable: probeDevServer(viteDevServer, v.config.Probe.Timeout),
+		checkedAt: time.Now(),
+	}
+
+	v.mu.Lock()
+	v.probe = probe
+	v.mu.Unlock()
+
+	return probe.reachable
+}
+
+func probeDevServer(viteDevServer string, timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req, err := nethttp.NewRequestWithContext(ctx, nethttp.MethodHead, viteDevServer+"/@vite/client", nil)
+	if err != nil {
+		return false
+	}
+
+	resp, err := nethttp.DefaultClient.Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+
+	return true
+}
+
+// devServerBanner renders the notice shown in place of the tags when the hot
+// file names a dev server that does not answer.
+func devServerBanner(viteDevServer string) string {
+	return fmt.Sprintf(`<div style="position:fixed;inset:0 0 auto 0;z-index:2147483647;padding:12px 16px;background:#b91c1c;color:#fff;font:14px/1.5 system-ui,sans-serif">`+
+		`The Vite dev server at <strong>%s</strong> is not responding. Start it with <code>npm run dev</code>, or delete the hot file to use the production build.`+
+		`</div>`, template.HTMLEscapeString(viteDevServer))
+}
@@ -0,0 +1,73 @@
+package vite
+
+import (
+	"html/template"
+	nethttp "net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDevServer_ProbeIsCached(t *testing.T) {
+	var probes atomic.Int32
+	devServer := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		assert.Equal(t, nethttp.MethodHead, r.Method)
+		assert.Equal(t, "/@vite/client", r.URL.Path)
+		probes.Add(1)
+		w.WriteHeader(nethttp.StatusNotFound)
+	}))
+	defer devServer.Close()
+
+	config := testConfig(t.TempDir())
+	config.Probe = ProbeConfig{Enabled: true, Interval: time.Minute, Timeout: time.Second}
+	require.NoError(t, os.WriteFile(config.HotFile, []byte(devServer.URL), 0644))
+
+	vite := NewViteWithConfig(config)
+	for range 2 {
+		viteDevServer, hot, down := vite.devServer()
+		assert.Equal(t, devServer.URL, viteDevServer)
+		assert.True(t, hot)
+		assert.False(t, down)
+	}
+
+	assert.Equal(t, int32(1), probes.Load())
+}
+
+func TestAssets_DevServerDown_FallsBackToBuild(t *testing.T) {
+	config := testConfig(t.TempDir())
+	config.Probe.Enabled = true
+	require.NoError(t, os.WriteFile(config.HotFile, []byte(closedServerURL()), 0644))
+	require.NoError(t, os.WriteFile(config.ManifestPath, []byte(`{"resources/js/app.js": {"file": "assets/app.12345.js", "isEntry": true}}`), 0644))
+
+	html := NewViteWithConfig(config).Assets("resources/js/app.js")
+
+	assert.Equal(t, template.HTML(`<link rel="modulepreload" href="/static/assets/app.12345.js"><script type="module" src="/static/assets/app.12345.js"></script>`), html)
+}
+
+func TestAssets_DevServerDown_RendersBanner(t *testing.T) {
+	viteDevServer := closedServerURL()
+	config := testConfig(t.TempDir())
+	config.Probe.Enabled = true
+	config.Probe.Fallback = "banner"
+	require.NoError(t, os.WriteFile(config.HotFile, []byte(viteDevServer), 0644))
+
+	html := string(NewViteWithConfig(config).Assets("resources/js/app.js"))
+
+	assert.Contains(t, html, "The Vite dev server at <strong>"+viteDevServer+"</strong> is not responding.")
+	assert.Contains(t, html, "<code>npm run dev</code>")
+	assert.NotContains(t, html, "<script")
+}
+
+// closedServerURL returns the URL of a server that has already shut down, so
+// connecting to it fails right away.
+func closedServerURL() string {
+	server := httptest.NewServer(nethttp.NotFoundHandler())
+	server.Close()
+
+	return server.URL
+}
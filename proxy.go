@@ -0,0 +1,66 @@
+package vite
+
+import (
+	nethttp "net/http"
+	"net/http/httputil"
+	"net/url"
+
+	"github.com/goravel/framework/contracts/http"
+)
+
+// devProxyPaths are the routes the Vite dev server answers on while a page is
+// served by Goravel: the client and HMR socket, virtual and file system
+// modules, the React Refresh runtime, the source files and prebundled deps.
+var devProxyPaths = []string{
+	"/@vite/*path",
+	"/@id/*path",
+	"/@fs/*path",
+	"/@react-refresh",
+	"/resources/*path",
+	"/node_modules/*path",
+}
+
+// devAssetURL returns the URL prefix of files served by the dev server. With
+// vite.dev_proxy enabled they are requested from the application's own origin
+// and forwarded to the dev server by the proxy routes.
+func (v *Vite) devAssetURL(viteDevServer string) string {
+	if v.config.DevProxy {
+		return ""
+	}
+
+	return viteDevServer
+}
+
+func (v *Vite) serveDevProxy(ctx http.Context) http.Response {
+	v.proxyDevServer(ctx.Response().Writer(), ctx.Request().Origin())
+
+	return nil
+}
+
+// proxyDevServer forwards a request, including a WebSocket upgrade, to the dev
+// server named in the hot file. The target is resolved on every request, so
+// restarting Vite on another port needs no restart of the application.
+func (v *Vite) proxyDevServer(w nethttp.ResponseWriter, r *nethttp.Request) {
+	viteDevServer, hot := v.hotFile()
+	if !hot {
+		nethttp.Error(w, "Vite dev server is not running", nethttp.StatusServiceUnavailable)
+		return
+	}
+
+	target, err := url.Parse(viteDevServer)
+	if err != nil || target.Host == "" {
+		nethttp.Error(w, "invalid Vite dev server URL", nethttp.StatusBadGateway)
+		return
+	}
+
+	proxy := &httputil.ReverseProxy{
+		// SetURL also rewrites the Host header, which Vite checks against
+		// server.allowedHosts.
+		Rewrite: func(r *httputil.ProxyRequest) {
+			r.SetURL(target)
+			r.SetXForwarded()
+		},
+	}
+
+	proxy.ServeHTTP(w, r)
+}
@@ -0,0 +1,111 @@
+package vite
+
+import (
+	"bufio"
+	"fmt"
+	"html/template"
+	"io"
+	"net"
+	nethttp "net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProxyDevServer(t *testing.T) {
+	devServer := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		_, _ = fmt.Fprintf(w, "%s %s", r.Host, r.URL.RequestURI())
+	}))
+	defer devServer.Close()
+
+	config := testConfig(t.TempDir())
+	require.NoError(t, os.WriteFile(config.HotFile, []byte(devServer.URL), 0644))
+
+	app := httptest.NewServer(nethttp.HandlerFunc(NewViteWithConfig(config).proxyDevServer))
+	defer app.Close()
+
+	resp, err := nethttp.Get(app.URL + "/node_modules/.vite/deps/vue.js?v=123")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, nethttp.StatusOK, resp.StatusCode)
+	assert.Equal(t, devServer.Listener.Addr().String()+" /node_modules/.vite/deps/vue.js?v=123", string(body))
+}
+
+func TestProxyDevServer_WebSocketUpgrade(t *testing.T) {
+	devServer := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		if r.Header.Get("Upgrade") != "websocket" || r.URL.Path != "/@vite/hmr" {
+			w.WriteHeader(nethttp.StatusBadRequest)
+			return
+		}
+
+		conn, rw, err := nethttp.NewResponseController(w).Hijack()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		_, _ = rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Protocol: vite-hmr\r\n\r\n")
+		_ = rw.Flush()
+
+		line, _ := rw.ReadString('\n')
+		_, _ = rw.WriteString("echo: " + line)
+		_ = rw.Flush()
+	}))
+	defer devServer.Close()
+
+	config := testConfig(t.TempDir())
+	require.NoError(t, os.WriteFile(config.HotFile, []byte(devServer.URL), 0644))
+
+	app := httptest.NewServer(nethttp.HandlerFunc(NewViteWithConfig(config).proxyDevServer))
+	defer app.Close()
+
+	conn, err := net.Dial("tcp", app.Listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = fmt.Fprintf(conn, "GET /@vite/hmr HTTP/1.1\r\nHost: %s\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Protocol: vite-hmr\r\n\r\n", app.Listener.Addr())
+	require.NoError(t, err)
+
+	reader := bufio.NewReader(conn)
+	resp, err := nethttp.ReadResponse(reader, nil)
+	require.NoError(t, err)
+	assert.Equal(t, nethttp.StatusSwitchingProtocols, resp.StatusCode)
+	assert.Equal(t, "vite-hmr", resp.Header.Get("Sec-WebSocket-Protocol"))
+
+	_, err = conn.Write([]byte("ping\n"))
+	require.NoError(t, err)
+
+	line, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "echo: ping\n", line)
+}
+
+func TestProxyDevServer_NotRunning(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	NewViteWithConfig(testConfig(t.TempDir())).proxyDevServer(recorder, httptest.NewRequest(nethttp.MethodGet, "/@vite/client", nil))
+
+	assert.Equal(t, nethttp.StatusServiceUnavailable, recorder.Code)
+}
+
+func TestAssets_DevProxy(t *testing.T) {
+	config := testConfig(t.TempDir())
+	config.JSFramework = "react"
+	config.DevProxy = true
+	require.NoError(t, os.WriteFile(config.HotFile, []byte("http://localhost:5173"), 0644))
+
+	vite := NewViteWithConfig(config)
+	html := vite.Assets("resources/js/app.tsx")
+
+	assert.Contains(t, html, template.HTML(`import RefreshRuntime from "/@react-refresh";`))
+	assert.Contains(t, html, template.HTML(`<script type="module" src="/@vite/client"></script><script type="module" src="/resources/js/app.tsx"></script>`))
+
+	url, err := vite.Asset("resources/images/logo.svg")
+	require.NoError(t, err)
+	assert.Equal(t, "/resources/images/logo.svg", url)
+}
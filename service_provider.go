@@ -1,7 +1,13 @@
 package vite
 
 import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/goravel/framework/contracts/console"
 	"github.com/goravel/framework/contracts/foundation"
+	"github.com/goravel/framework/support/env"
 	"github.com/goravel/framework/support/path"
 )
 
@@ -15,7 +21,23 @@ type ServiceProvider struct {
 func (receiver *ServiceProvider) Register(app foundation.Application) {
 	App = app
 
-	vite := NewVite(app.MakeConfig())
+	config := app.MakeConfig()
+	viteConfig := LoadConfig(config)
+	if err := viteConfig.Validate(); err != nil {
+		panic(err)
+	}
+
+	// Artisan commands such as vite:build run before the build exists.
+	if config.GetString("app.env") == "production" && !env.IsArtisan() {
+		if err := viteConfig.checkBuildPaths(); err != nil {
+			panic(err)
+		}
+	}
+
+	// Set here rather than in Boot so pages rendered before this provider
+	// boots still report missing entries.
+	viteConfig.Logger = app.MakeLog()
+	vite := NewViteWithConfig(viteConfig)
 
 	app.Bind(Binding, func(app foundation.Application) (any, error) {
 		return vite, nil
@@ -24,9 +46,35 @@ func (receiver *ServiceProvider) Register(app foundation.Application) {
 
 func (receiver *ServiceProvider) Boot(app foundation.Application) {
 
-	route := app.MakeRoute()
-	config := app.MakeConfig()
-	route.Static(config.GetString("vite.base_url", "/static"), path.Base(config.GetString("vite.assets_path", "public/build")))
+	app.Commands([]console.Command{
+		NewInstallCommand(app),
+		NewDoctorCommand(app),
+		NewManifestCommand(app),
+		NewDevCommand(app),
+		NewBuildCommand(app),
+	})
+
+	if instance, err := app.Make(Binding); err == nil {
+		vite := instance.(*Vite)
+
+		// The published templates call {{ .vite.Assets }}, which needs no
+		// FuncMap; an application that shares its own "vite" value keeps it.
+		if view := app.MakeView(); view != nil && view.Shared("vite") == nil {
+			view.Share("vite", vite)
+		}
+
+		if vite.config.ServeAssets {
+			receiver.registerAssets(app, vite)
+		}
+
+		if vite.config.DevProxy {
+			receiver.registerDevProxy(app, vite)
+		}
+
+		if vite.config.WatchManifest && vite.config.FS == nil {
+			receiver.watchManifest(app, vite)
+		}
+	}
 
 	app.Publishes("github.com/merouanekhalili/goravel-vite", map[string]string{
 		"config/vite.go":                       app.ConfigPath("vite.go"),
@@ -58,4 +106,68 @@ func (receiver *ServiceProvider) Boot(app foundation.Application) {
 		"templates/vue/components.json.txt":  path.Base("components.json"),
 		"templates/vue/eslint.config.js.txt": path.Base("eslint.config.js"),
 	}, "vue")
+
+	app.Publishes("github.com/merouanekhalili/goravel-vite", map[string]string{
+		"config/vite.go":                                   app.ConfigPath("vite.go"),
+		"templates/.prettierignore.txt":                    path.Base(".prettierignore"),
+		"templates/.prettierrc.txt":                        path.Base(".prettierrc"),
+		"templates/inertia/react/views":                    path.Base("resources/views"),
+		"templates/inertia/react/js/main.tsx.txt":          path.Base("resources/js/main.tsx"),
+		"templates/inertia/react/js/ssr.tsx.txt":           path.Base("resources/js/ssr.tsx"),
+		"templates/inertia/react/js/pages/Welcome.tsx.txt": path.Base("resources/js/pages/Welcome.tsx"),
+		"templates/react/css/app.css.txt":                  path.Base("resources/css/app.css"),
+		"templates/react/vite.config.ts.txt":               path.Base("vite.config.ts"),
+		"templates/inertia/react/package.json.txt":         path.Base("package.json"),
+		"templates/react/tsconfig.json.txt":                path.Base("tsconfig.json"),
+		"templates/react/components.json.txt":              path.Base("components.json"),
+		"templates/react/eslint.config.js.txt":             path.Base("eslint.config.js"),
+	}, "inertia-react")
+
+	app.Publishes("github.com/merouanekhalili/goravel-vite", map[string]string{
+		"config/vite.go":                                 app.ConfigPath("vite.go"),
+		"templates/.prettierignore.txt":                  path.Base(".prettierignore"),
+		"templates/.prettierrc.txt":                      path.Base(".prettierrc"),
+		"templates/inertia/vue/views":                    path.Base("resources/views"),
+		"templates/inertia/vue/js/main.ts.txt":           path.Base("resources/js/main.ts"),
+		"templates/inertia/vue/js/ssr.ts.txt":            path.Base("resources/js/ssr.ts"),
+		"templates/inertia/vue/js/pages/Welcome.vue.txt": path.Base("resources/js/pages/Welcome.vue"),
+		"templates/vue/js/env.d.ts.txt":                  path.Base("resources/js/env.d.ts"),
+		"templates/vue/css/app.css.txt":                  path.Base("resources/css/app.css"),
+		"templates/vue/vite.config.ts.txt":               path.Base("vite.config.ts"),
+		"templates/inertia/vue/package.json.txt":         path.Base("package.json"),
+		"templates/vue/tsconfig.json.txt":                path.Base("tsconfig.json"),
+		"templates/vue/components.json.txt":              path.Base("components.json"),
+		"templates/vue/eslint.config.js.txt":             path.Base("eslint.config.js"),
+	}, "inertia-vue")
+}
+
+// registerAssets serves the built assets under vite.base_url, from the assets
+// file system when one is configured and from vite.assets_path otherwise.
+func (receiver *ServiceProvider) registerAssets(app foundation.Application, vite *Vite) {
+	fsys := vite.config.FS
+	if fsys == nil {
+		fsys = os.DirFS(path.Base(vite.config.AssetsPath))
+	}
+
+	app.MakeRoute().Any(strings.TrimRight(vite.config.BaseURL, "/")+"/*path", vite.newAssetServer(fsys).handle)
+}
+
+func (receiver *ServiceProvider) registerDevProxy(app foundation.Application, vite *Vite) {
+	route := app.MakeRoute()
+	for _, path := range devProxyPaths {
+		route.Any(path, vite.serveDevProxy)
+	}
+}
+
+func (receiver *ServiceProvider) watchManifest(app foundation.Application, vite *Vite) {
+	logger := app.MakeLog()
+
+	go vite.WatchManifest(context.Background(), vite.config.WatchInterval, vite.config.WatchDebounce, func(err error) {
+		if err != nil {
+			logger.Errorf("vite: failed to reload manifest: %v", err)
+			return
+		}
+
+		logger.Info("vite: manifest reloaded")
+	})
 }
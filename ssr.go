@@ -0,0 +1,143 @@
+package vite
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/merouanekhalili/goravel-vite/contracts"
+)
+
+// ssrManifest maps module IDs to the built files they need, as written by
+// vite build --ssrManifest.
+type ssrManifest map[string][]string
+
+type ssrResult struct {
+	Head    []string `json:"head"`
+	Body    string   `json:"body"`
+	Modules []string `json:"modules,omitempty"`
+}
+
+// SSR posts the page to the SSR process and returns the rendered markup, with
+// preload tags for the chunks used by the rendered modules appended to the
+// head. It returns nil without an error when SSR is disabled, and nil with an
+// error when the process fails, so callers can fall back to client rendering.
+// An unreadable SSR manifest only drops the preloads and is logged once.
+func (v *Vite) SSR(ctx context.Context, page any) (*contracts.SSRResponse, error) {
+	if !v.config.SSR.Enabled {
+		return nil, nil
+	}
+
+	payload, err := json.Marshal(page)
+	if err != nil {
+		return nil, fmt.Errorf("encoding SSR page: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, v.config.SSR.Timeout)
+	defer cancel()
+
+	url := v.config.SSR.URL
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("creating SSR request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("calling SSR server %q: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("SSR server %q responded with status %d", url, resp.StatusCode)
+	}
+
+	var result ssrResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("parsing SSR response: %w", err)
+	}
+
+	head := strings.Join(result.Head, "\n")
+	if len(result.Modules) > 0 {
+		preloads, err := v.ssrPreloads(result.Modules)
+		if err != nil {
+			v.warn("vite: rendering SSR without preloads: %v", err)
+		}
+		head += preloads
+	}
+
+	return &contracts.SSRResponse{
+		Head: template.HTML(head),
+		Body: template.HTML(result.Body),
+	}, nil
+}
+
+// ssrPreloads renders preload tags for the files used by the given modules.
+func (v *Vite) ssrPreloads(modules []string) (string, error) {
+	manifest, err := v.loadSSRManifest()
+	if err != nil {
+		return "", err
+	}
+
+	baseURL, crossOrigin := v.assetURL()
+	crossOriginAttr := ""
+	if crossOrigin != "" {
+		crossOriginAttr = fmt.Sprintf(` crossorigin="%s"`, template.HTMLEscapeString(crossOrigin))
+	}
+	seen := make(map[string]bool)
+
+	var sb strings.Builder
+	for _, module := range modules {
+		for _, file := range manifest[module] {
+			if seen[file] {
+				continue
+			}
+			seen[file] = true
+
+			href := baseURL + strings.TrimPrefix(file, "/")
+			switch strings.ToLower(filepath.Ext(file)) {
+			case ".js", ".mjs":
+				sb.WriteString(fmt.Sprintf(`<link rel="modulepreload" href="%s"%s>`, href, crossOriginAttr))
+			case ".css":
+				sb.WriteString(fmt.Sprintf(`<link rel="stylesheet" href="%s"%s>`, href, crossOriginAttr))
+			case ".woff", ".woff2":
+				sb.WriteString(fmt.Sprintf(`<link rel="preload" href="%s" as="font" type="font/%s" crossorigin>`, href, strings.TrimPrefix(filepath.Ext(file), ".")))
+			case ".gif", ".jpg", ".jpeg", ".png", ".svg", ".webp", ".avif":
+				sb.WriteString(fmt.Sprintf(`<link rel="preload" href="%s" as="image"%s>`, href, crossOriginAttr))
+			}
+		}
+	}
+
+	return sb.String(), nil
+}
+
+func (v *Vite) loadSSRManifest() (ssrManifest, error) {
+	v.mu.RLock()
+	manifest := v.ssrManifest
+	v.mu.RUnlock()
+
+	if manifest != nil {
+		return manifest, nil
+	}
+
+	data, manifestPath, err := v.readBuildFile(v.config.SSR.ManifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading SSR manifest file %q: %w", manifestPath, err)
+	}
+
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("parsing SSR manifest JSON %q: %w", manifestPath, err)
+	}
+
+	v.mu.Lock()
+	v.ssrManifest = manifest
+	v.mu.Unlock()
+
+	return manifest, nil
+}
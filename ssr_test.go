@@ -0,0 +1,131 @@
+package vite
+
+import (
+	"context"
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	mockslog "github.com/goravel/framework/mocks/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSSR_Disabled(t *testing.T) {
+	ssr, err := NewViteWithConfig(testConfig(t.TempDir())).SSR(context.Background(), map[string]any{"component": "Welcome"})
+
+	assert.NoError(t, err)
+	assert.Nil(t, ssr)
+}
+
+func TestSSR(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var page map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&page))
+		assert.Equal(t, "Welcome", page["component"])
+
+		_, _ = w.Write([]byte(`{
+			"head": ["<title>Welcome</title>", "<meta name=\"description\" content=\"Hi\">"],
+			"body": "<div id=\"app\">Hello</div>",
+			"modules": ["resources/js/pages/Welcome.vue", "resources/js/components/Button.vue"]
+		}`))
+	}))
+	defer server.Close()
+
+	config := testConfig(t.TempDir())
+	config.SSR.Enabled = true
+	config.SSR.URL = server.URL
+	config.SSR.Timeout = time.Second
+	require.NoError(t, os.WriteFile(config.SSR.ManifestPath, []byte(`{
+		"resources/js/pages/Welcome.vue": ["/assets/Welcome.1.js", "/assets/Welcome.1.css", "/assets/Inter.woff2"],
+		"resources/js/components/Button.vue": ["/assets/Welcome.1.js", "/assets/logo.png"],
+		"resources/js/pages/Other.vue": ["/assets/Other.1.js"]
+	}`), 0644))
+
+	vite := NewViteWithConfig(config)
+	ssr, err := vite.SSR(context.Background(), map[string]any{"component": "Welcome"})
+	require.NoError(t, err)
+
+	assert.Equal(t, template.HTML(`<title>Welcome</title>`+"\n"+`<meta name="description" content="Hi">`+
+		`<link rel="modulepreload" href="/static/assets/Welcome.1.js">`+
+		`<link rel="stylesheet" href="/static/assets/Welcome.1.css">`+
+		`<link rel="preload" href="/static/assets/Inter.woff2" as="font" type="font/woff2" crossorigin>`+
+		`<link rel="preload" href="/static/assets/logo.png" as="image">`), ssr.Head)
+	assert.Equal(t, template.HTML(`<div id="app">Hello</div>`), ssr.Body)
+
+	_, err = vite.SSR(context.Background(), map[string]any{"component": "Welcome"})
+	require.NoError(t, err, "SSR manifest should be cached")
+}
+
+func TestSSR_ServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	config := testConfig(t.TempDir())
+	config.SSR.Enabled = true
+	config.SSR.URL = server.URL
+	config.SSR.Timeout = time.Second
+
+	ssr, err := NewViteWithConfig(config).SSR(context.Background(), map[string]any{})
+
+	assert.Nil(t, ssr)
+	assert.ErrorContains(t, err, "responded with status 500")
+}
+
+func TestSSR_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	config := testConfig(t.TempDir())
+	config.SSR.Enabled = true
+	config.SSR.URL = server.URL
+	config.SSR.Timeout = 20 * time.Millisecond
+
+	ssr, err := NewViteWithConfig(config).SSR(context.Background(), map[string]any{})
+
+	assert.Nil(t, ssr)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestSSR_MissingManifest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"head": ["<title>Welcome</title>"], "body": "<div id=\"app\">Hello</div>", "modules": ["resources/js/pages/Welcome.vue"]}`))
+	}))
+	defer server.Close()
+
+	config := testConfig(t.TempDir())
+	config.SSR.Enabled = true
+	config.SSR.URL = server.URL
+	config.SSR.Timeout = time.Second
+
+	mockLog := mockslog.NewLog(t)
+	mockLog.EXPECT().Warning(mock.MatchedBy(func(message string) bool {
+		return strings.HasPrefix(message, "vite: rendering SSR without preloads: reading SSR manifest file")
+	})).Once()
+	config.Logger = mockLog
+
+	vite := NewViteWithConfig(config)
+
+	for range 2 {
+		ssr, err := vite.SSR(context.Background(), map[string]any{"component": "Welcome"})
+		require.NoError(t, err)
+		assert.Equal(t, template.HTML(`<title>Welcome</title>`), ssr.Head)
+		assert.Equal(t, template.HTML(`<div id="app">Hello</div>`), ssr.Body)
+	}
+}
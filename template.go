@@ -0,0 +1,70 @@
+package vite
+
+import (
+	"fmt"
+	"html/template"
+)
+
+// FuncMap returns the template functions exposed by the package. They are not
+// registered by the service provider: pass the map to the HTTP driver's
+// template options. The Vite instance is resolved from the container on every
+// call, so the map can be handed to the view engine before the application
+// has booted, and tags are rendered from the current manifest on every render.
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"vite": func(entries ...string) (template.HTML, error) {
+			instance, err := resolve()
+			if err != nil {
+				return "", err
+			}
+
+			return instance.Assets(entries...), nil
+		},
+		"vite_with_nonce": func(nonce string, entries ...string) (template.HTML, error) {
+			instance, err := resolve()
+			if err != nil {
+				return "", err
+			}
+
+			return instance.render(nonce, entries), nil
+		},
+		"vite_asset": func(path string) (string, error) {
+			instance, err := resolve()
+			if err != nil {
+				return "", err
+			}
+
+			return instance.Asset(path)
+		},
+		"vite_react_refresh": func(nonce ...string) (template.HTML, error) {
+			instance, err := resolve()
+			if err != nil {
+				return "", err
+			}
+
+			if len(nonce) > 0 {
+				return instance.renderReactRefresh(nonce[0]), nil
+			}
+
+			return instance.ReactRefresh(), nil
+		},
+	}
+}
+
+func resolve() (*Vite, error) {
+	if App == nil {
+		return nil, fmt.Errorf("vite service provider is not registered")
+	}
+
+	instance, err := App.Make(Binding)
+	if err != nil {
+		return nil, err
+	}
+
+	vite, ok := instance.(*Vite)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type %T bound to %s", instance, Binding)
+	}
+
+	return vite, nil
+}
@@ -0,0 +1,97 @@
+package vite
+
+import (
+	"html/template"
+	"os"
+	"strings"
+	"testing"
+
+	mocksfoundation "github.com/goravel/framework/mocks/foundation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFuncMap_ViteAsset(t *testing.T) {
+	config := testConfig(t.TempDir())
+	require.NoError(t, os.WriteFile(config.HotFile, []byte("http://localhost:5173"), 0644))
+
+	mockApp := mocksfoundation.NewApplication(t)
+
+	originalApp := App
+	defer func() {
+		App = originalApp
+	}()
+	App = mockApp
+
+	mockApp.EXPECT().Make(Binding).Return(NewViteWithConfig(config), nil).Once()
+
+	tmpl, err := template.New("app").Funcs(FuncMap()).Parse(`<img src="{{ vite_asset "resources/images/logo.svg" }}">`)
+	require.NoError(t, err)
+
+	var sb strings.Builder
+	require.NoError(t, tmpl.Execute(&sb, nil))
+	assert.Equal(t, `<img src="http://localhost:5173/resources/images/logo.svg">`, sb.String())
+}
+
+func TestFuncMap_Vite(t *testing.T) {
+	config := testConfig(t.TempDir())
+	require.NoError(t, os.WriteFile(config.HotFile, []byte("http://localhost:5173"), 0644))
+
+	mockApp := mocksfoundation.NewApplication(t)
+
+	originalApp := App
+	defer func() {
+		App = originalApp
+	}()
+	App = mockApp
+
+	mockApp.EXPECT().Make(Binding).Return(NewViteWithConfig(config), nil).Times(3)
+
+	tmpl, err := template.New("app").Funcs(FuncMap()).Parse(`{{ vite_react_refresh "abc" }}{{ vite "resources/js/admin.ts" }}{{ vite_with_nonce "abc" "resources/js/app.ts" }}`)
+	require.NoError(t, err)
+
+	var sb strings.Builder
+	require.NoError(t, tmpl.Execute(&sb, nil))
+
+	html := sb.String()
+	assert.True(t, strings.HasPrefix(html, `<script type="module" nonce="abc">`))
+	assert.Contains(t, html, `import RefreshRuntime from "http://localhost:5173/@react-refresh";`)
+	assert.Contains(t, html, `<script type="module" src="http://localhost:5173/resources/js/admin.ts"></script>`)
+	assert.Contains(t, html, `<script type="module" src="http://localhost:5173/resources/js/app.ts" nonce="abc"></script>`)
+}
+
+func TestFuncMap_NotRegistered(t *testing.T) {
+	originalApp := App
+	defer func() {
+		App = originalApp
+	}()
+	App = nil
+
+	tmpl, err := template.New("app").Funcs(FuncMap()).Parse(`{{ vite }}`)
+	require.NoError(t, err)
+
+	err = tmpl.Execute(&strings.Builder{}, nil)
+	assert.ErrorContains(t, err, "vite service provider is not registered")
+}
+
+func TestPublishedTemplates_WithoutFuncMap(t *testing.T) {
+	config := testConfig(t.TempDir())
+	require.NoError(t, os.WriteFile(config.HotFile, []byte("http://localhost:5173"), 0644))
+	vite := NewViteWithConfig(config)
+
+	for _, file := range []string{
+		"templates/react/views/app.tmpl",
+		"templates/vue/views/app.tmpl",
+		"templates/inertia/react/views/app.tmpl",
+		"templates/inertia/vue/views/app.tmpl",
+	} {
+		t.Run(file, func(t *testing.T) {
+			tmpl, err := template.ParseFiles(file)
+			require.NoError(t, err)
+
+			var sb strings.Builder
+			require.NoError(t, tmpl.ExecuteTemplate(&sb, "app.tmpl", map[string]any{"vite": vite}))
+			assert.Contains(t, sb.String(), `<script type="module" src="http://localhost:5173/@vite/client"></script>`)
+		})
+	}
+}
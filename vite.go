@@ -1,7 +1,6 @@
 package vite
 
 import (
-	"encoding/json"
 	"fmt"
 	"html/template"
 	"os"
@@ -11,163 +10,274 @@ import (
 	"github.com/merouanekhalili/goravel-vite/contracts"
 
 	"github.com/goravel/framework/contracts/config"
+	"github.com/goravel/framework/contracts/http"
 	"github.com/goravel/framework/support/path"
 )
 
-type viteManifest map[string]viteManifestEntry
-type viteManifestEntry struct {
-	File    string   `json:"file"`
-	IsEntry bool     `json:"isEntry,omitempty"`
-	Src     string   `json:"src,omitempty"`
-	CSS     []string `json:"css,omitempty"`
-	Assets  []string `json:"assets,omitempty"`
-	Imports []string `json:"imports,omitempty"`
-}
-
-var (
-	manifest        viteManifest
-	manifestOnce    sync.Once
-	manifestErr     error
-	entryPoints     []string
-	entryPointsOnce sync.Once
-)
-
 var _ contracts.Vite = &Vite{}
 
 type Vite struct {
-	config config.Config
+	config Config
+
+	mu          sync.RWMutex
+	build       *viteBuild
+	ssrManifest ssrManifest
+	probe       *devServerProbe
+	warned      sync.Map
+
+	// etags and compressed cache served files until the next Reload or Flush.
+	etags      sync.Map
+	compressed sync.Map
 }
 
+// NewVite creates a Vite from the vite namespace of the application config,
+// read once through LoadConfig.
 func NewVite(config config.Config) *Vite {
+	return NewViteWithConfig(LoadConfig(config))
+}
+
+// NewViteWithConfig creates a Vite from a typed configuration, for use without
+// the service provider. Call Validate on the configuration first to catch
+// invalid values.
+func NewViteWithConfig(config Config) *Vite {
 	return &Vite{config: config}
 }
 
-func (v *Vite) Assets() template.HTML {
+// Assets renders the tags for the given manifest entries. When no entries
+// are given, the configured vite.entry_points are used.
+func (v *Vite) Assets(entries ...string) template.HTML {
+	return v.render("", entries)
+}
 
-	env := v.config.GetString("app.env", "production")
-	jsFramework := v.config.GetString("vite.js_framework", "vue")
+// AssetsWithNonce renders the same tags as Assets, adding the nonce generated
+// by the ContentSecurityPolicy middleware for the current request.
+func (v *Vite) AssetsWithNonce(ctx http.Context, entries ...string) template.HTML {
+	return v.render(CSPNonce(ctx), entries)
+}
 
-	entryPointsOnce.Do(func() {
-		entryPoints = strings.Split(v.config.GetString("vite.entry_points", ""), ",")
-	})
+// Nonce returns the CSP nonce of the current request, so templates given the
+// request context can add it to their own inline scripts as
+// nonce="{{ .vite.Nonce .ctx }}". It is empty when ctx is nil.
+func (v *Vite) Nonce(ctx http.Context) string {
+	return CSPNonce(ctx)
+}
 
-	var sb strings.Builder
+// ReactRefresh renders the React Refresh preamble while the dev server is
+// running. Assets already includes it when vite.js_framework is "react".
+func (v *Vite) ReactRefresh() template.HTML {
+	return v.renderReactRefresh("")
+}
 
-	if env == "local" {
+func (v *Vite) renderReactRefresh(nonce string) template.HTML {
+	viteDevServer, hot := v.hotServer()
+	if !hot {
+		return ""
+	}
 
-		viteDevServer := v.config.GetString("vite.dev_server_url", "http://localhost:5173")
+	return template.HTML(reactRefresh(v.devAssetURL(viteDevServer), nonceAttribute(nonce)))
+}
 
-		if jsFramework == "react" {
-			sb.WriteString(`<script type="module">
-			import RefreshRuntime from "` + viteDevServer + `/@react-refresh";
-			RefreshRuntime.injectIntoGlobalHook(window);
-			window.$RefreshReg$ = () => {};
-			window.$RefreshSig$ = () => (type) => type;
-			window.__vite_plugin_react_preamble_installed__ = true;
-			</script>`)
-		}
+func (v *Vite) render(nonce string, entries []string) template.HTML {
 
-		sb.WriteString(fmt.Sprintf(`<script type="module" src="%s/@vite/client"></script>`, viteDevServer))
+	viteDevServer, hot, down := v.devServer()
+	jsFramework := v.config.JSFramework
 
-		for _, entry := range entryPoints {
-			sb.WriteString(fmt.Sprintf(`<script type="module" src="%s/%s"></script>`, viteDevServer, entry))
-		}
+	entries = v.resolveEntries(entries)
 
-	} else {
+	var sb strings.Builder
 
-		manifest, manifestErr = v.loadManifest()
-		if manifestErr != nil {
-			return template.HTML(fmt.Sprintf("<!-- ERROR: Could not load Vite manifest: %v -->", manifestErr))
-		}
+	nonceAttr := nonceAttribute(nonce)
 
-		includedCSS := make(map[string]bool)
-		includedJSPreload := make(map[string]bool)
-		includedCSSPreload := make(map[string]bool)
-		baseURL := v.config.GetString("vite.base_url", "/static/")
+	if down {
 
-		if !strings.HasSuffix(baseURL, "/") {
-			baseURL += "/"
-		}
+		sb.WriteString(devServerBanner(viteDevServer))
 
-		var preloadJS func(string)
-		preloadJS = func(moduleSrc string) {
-			if includedJSPreload[moduleSrc] {
-				return
-			}
-			entry, ok := manifest[moduleSrc]
-			if !ok {
-				return
-			}
+	} else if hot {
 
-			jsPath := baseURL + entry.File
-			sb.WriteString(fmt.Sprintf(`<link rel="modulepreload" href="%s">`, jsPath))
-			includedJSPreload[moduleSrc] = true
+		viteDevServer = v.devAssetURL(viteDevServer)
 
-			for _, imp := range entry.Imports {
-				preloadJS(imp)
-			}
+		if jsFramework == "react" {
+			sb.WriteString(reactRefresh(viteDevServer, nonceAttr))
 		}
 
-		for _, entrySrc := range entryPoints {
-			entry, ok := manifest[entrySrc]
-			if !ok {
-				continue
-			}
+		sb.WriteString(fmt.Sprintf(`<script type="module" src="%s/@vite/client"%s></script>`, viteDevServer, nonceAttr))
+
+		for _, entry := range entries {
+			sb.WriteString(fmt.Sprintf(`<script type="module" src="%s/%s"%s></script>`, viteDevServer, entry, nonceAttr))
+		}
 
-			preloadJS(entrySrc)
+	} else {
 
-			for _, cssFile := range entry.CSS {
-				if !includedCSSPreload[cssFile] {
-					cssPath := baseURL + cssFile
-					sb.WriteString(fmt.Sprintf(`<link rel="preload" href="%s" as="style">`, cssPath))
-					includedCSSPreload[cssFile] = true
-				}
+		build, err := v.loadManifest()
+		if err != nil {
+			return template.HTML(fmt.Sprintf("<!-- ERROR: Could not load Vite manifest: %v -->", err))
+		}
+		manifest := build.manifest
+
+		includedCSS := make(map[string]bool)
+		baseURL, crossOrigin := v.assetURL()
+
+		for _, p := range build.preloads(entries) {
+			if p.style {
+				sb.WriteString(fmt.Sprintf(`<link rel="preload" href="%s" as="style"%s%s>`, baseURL+p.file, build.attributes(p.file, crossOrigin), nonceAttr))
+			} else {
+				sb.WriteString(fmt.Sprintf(`<link rel="modulepreload" href="%s"%s%s>`, baseURL+p.file, build.attributes(p.file, crossOrigin), nonceAttr))
 			}
 		}
 
-		for _, entrySrc := range entryPoints {
+		for _, entrySrc := range entries {
 			entry, ok := manifest[entrySrc]
 			if !ok {
+				v.warn("vite: entry point %q is not in the manifest; add it to build.rollupOptions.input or fix vite.entry_points", entrySrc)
 				continue
 			}
 
 			if strings.HasSuffix(strings.ToLower(entry.File), ".js") {
 				jsPath := baseURL + entry.File
-				sb.WriteString(fmt.Sprintf(`<script type="module" src="%s"></script>`, jsPath))
+				sb.WriteString(fmt.Sprintf(`<script type="module" src="%s"%s%s></script>`, jsPath, build.attributes(entry.File, crossOrigin), nonceAttr))
 			}
 
 			for _, cssFile := range entry.CSS {
 				if !includedCSS[cssFile] {
 					cssPath := baseURL + cssFile
-					sb.WriteString(fmt.Sprintf(`<link rel="stylesheet" href="%s">`, cssPath))
+					sb.WriteString(fmt.Sprintf(`<link rel="stylesheet" href="%s"%s%s>`, cssPath, build.attributes(cssFile, crossOrigin), nonceAttr))
 					includedCSS[cssFile] = true
 				}
 			}
 		}
+
+		sb.WriteString(v.prefetchScript(build, entries, baseURL, crossOrigin, nonceAttr))
 	}
 
 	return template.HTML(sb.String())
 }
 
-func (v *Vite) loadManifest() (viteManifest, error) {
-	manifestOnce.Do(func() {
+func reactRefresh(viteDevServer, nonceAttr string) string {
+	return `<script type="module"` + nonceAttr + `>
+			import RefreshRuntime from "` + viteDevServer + `/@react-refresh";
+			RefreshRuntime.injectIntoGlobalHook(window);
+			window.$RefreshReg$ = () => {};
+			window.$RefreshSig$ = () => (type) => type;
+			window.__vite_plugin_react_preamble_installed__ = true;
+			</script>`
+}
 
-		manifestPath := path.Base(v.config.GetString("vite.manifest_path", "public/build/.vite/manifest.json"))
+func nonceAttribute(nonce string) string {
+	if nonce == "" {
+		return ""
+	}
 
-		data, err := os.ReadFile(manifestPath)
-		if err != nil {
-			manifestErr = fmt.Errorf("reading manifest file %q: %w", manifestPath, err)
-			return
-		}
+	return fmt.Sprintf(` nonce="%s"`, template.HTMLEscapeString(nonce))
+}
 
-		var m viteManifest
-		err = json.Unmarshal(data, &m)
-		if err != nil {
-			manifestErr = fmt.Errorf("parsing manifest JSON %q: %w", manifestPath, err)
-			return
-		}
-		manifest = m
-		manifestErr = nil
-	})
-	return manifest, manifestErr
+// Asset resolves a source file processed by Vite, such as an image or font,
+// to the URL it is served from.
+func (v *Vite) Asset(src string) (string, error) {
+	if viteDevServer, hot := v.hotServer(); hot {
+		return v.devAssetURL(viteDevServer) + "/" + strings.TrimPrefix(src, "/"), nil
+	}
+
+	build, err := v.loadManifest()
+	if err != nil {
+		return "", err
+	}
+
+	entry, ok := build.manifest[src]
+	if !ok {
+		return "", fmt.Errorf("unable to locate file in Vite manifest: %s", src)
+	}
+
+	baseURL, _ := v.assetURL()
+
+	return baseURL + entry.File, nil
+}
+
+// ManifestHash returns a hash of the loaded manifest, which changes with every
+// build that produces different assets.
+func (v *Vite) ManifestHash() (string, error) {
+	build, err := v.loadManifest()
+	if err != nil {
+		return "", err
+	}
+
+	return build.hash, nil
+}
+
+// assetURL returns the URL prefix of built files together with the crossorigin
+// value their tags need. Files are served from vite.asset_url when it is set,
+// typically a CDN, and from the local vite.base_url route otherwise.
+func (v *Vite) assetURL() (string, string) {
+	if v.config.AssetURL == "" {
+		return v.baseURL(), ""
+	}
+
+	return strings.TrimRight(v.config.AssetURL, "/") + "/", v.config.CrossOrigin
+}
+
+func (v *Vite) baseURL() string {
+	baseURL := v.config.BaseURL
+
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+
+	return baseURL
+}
+
+// Reload reads the manifest from disk again and swaps it in, dropping the
+// ETags and compressed files of the previous build. The previously loaded
+// manifest is kept when reading fails.
+func (v *Vite) Reload() error {
+	build, err := v.readManifest()
+	if err != nil {
+		return err
+	}
+
+	v.mu.Lock()
+	v.build = build
+	v.ssrManifest = nil
+	v.mu.Unlock()
+	v.warned.Clear()
+	v.etags.Clear()
+	v.compressed.Clear()
+
+	return nil
+}
+
+// Flush drops the cached manifest, dev server probe, ETags and compressed
+// files so they are read again on the next call.
+func (v *Vite) Flush() {
+	v.mu.Lock()
+	v.build = nil
+	v.ssrManifest = nil
+	v.probe = nil
+	v.mu.Unlock()
+	v.warned.Clear()
+	v.etags.Clear()
+	v.compressed.Clear()
+}
+
+// hotServer reports whether the assets of the Vite dev server should be used
+// and returns the URL it listens on. See devServer for how a hot file naming
+// an unreachable server is handled.
+func (v *Vite) hotServer() (string, bool) {
+	viteDevServer, hot, _ := v.devServer()
+
+	return viteDevServer, hot
+}
+
+// hotFile reads the URL of the dev server from the hot file it writes on
+// startup, and reports whether the file exists.
+func (v *Vite) hotFile() (string, bool) {
+	data, err := os.ReadFile(path.Base(v.config.HotFile))
+	if err != nil {
+		return "", false
+	}
+
+	url := strings.TrimRight(strings.TrimSpace(string(data)), "/")
+	if url == "" {
+		url = v.config.DevServerURL
+	}
+
+	return url, true
 }
@@ -4,42 +4,43 @@ import (
 	"html/template"
 	"os"
 	"path/filepath"
-	"sync"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
-	mocksconfig "github.com/goravel/framework/mocks/config"
+	mockshttp "github.com/goravel/framework/mocks/http"
+	mockslog "github.com/goravel/framework/mocks/log"
 )
 
+// testConfig returns the default configuration with the hot file, the
+// manifests and the assets under dir, and the dev server probe disabled.
+func testConfig(dir string) Config {
+	config := DefaultConfig()
+	config.HotFile = filepath.Join(dir, "hot")
+	config.AssetsPath = dir
+	config.ManifestPath = filepath.Join(dir, "manifest.json")
+	config.SSR.ManifestPath = filepath.Join(dir, "ssr-manifest.json")
+	config.Probe.Enabled = false
+
+	return config
+}
+
 type ViteTestSuite struct {
 	suite.Suite
 
-	mockConfig *mocksconfig.Config
-	vite       *Vite
-	tempDir    string
-}
-
-func resetGlobals() {
-	manifestOnce = sync.Once{}
-	entryPointsOnce = sync.Once{}
-	manifest = nil
-	manifestErr = nil
-	entryPoints = nil
+	config  Config
+	tempDir string
 }
 
 func (s *ViteTestSuite) SetupTest() {
-	resetGlobals()
-
-	s.mockConfig = mocksconfig.NewConfig(s.T())
-
-	s.vite = NewVite(s.mockConfig)
-
 	dir, err := os.MkdirTemp("", "vite_test_manifest_")
 	s.Require().NoError(err)
 	s.tempDir = dir
 
+	s.config = testConfig(dir)
 }
 
 func (s *ViteTestSuite) TearDownTest() {
@@ -49,39 +50,40 @@ func (s *ViteTestSuite) TearDownTest() {
 
 func (s *ViteTestSuite) writeManifest(content string) {
 
-	manifestPath := s.mockConfig.GetString("vite.manifest_path", "public/build/.vite/manifest.json")
-	err := os.WriteFile(manifestPath, []byte(content), 0644)
+	err := os.WriteFile(s.config.ManifestPath, []byte(content), 0644)
 	s.Require().NoError(err, "Failed to write mock manifest file")
 }
 
+func (s *ViteTestSuite) writeHotFile(content string) {
+
+	err := os.WriteFile(filepath.Join(s.tempDir, "hot"), []byte(content), 0644)
+	s.Require().NoError(err, "Failed to write mock hot file")
+}
+
 func TestViteTestSuite(t *testing.T) {
 	suite.Run(t, new(ViteTestSuite))
 }
 
 func (s *ViteTestSuite) TestAssets_LocalEnvironment_DefaultFramework() {
 
-	s.mockConfig.ExpectedCalls = nil
-	s.mockConfig.On("GetString", "app.env", "production").Return("local").Once()
-	s.mockConfig.On("GetString", "vite.js_framework", "vue").Return("vue").Once()
-	s.mockConfig.On("GetString", "vite.dev_server_url", "http://localhost:5173").Return("http://localhost:5173").Once()
-	s.mockConfig.On("GetString", "vite.entry_points", "").Return("resources/js/app.js").Once()
+	s.config.EntryPoints = []string{"resources/js/app.js"}
+	vite := NewViteWithConfig(s.config)
+	s.writeHotFile("http://localhost:5173")
 
 	expected := template.HTML(`<script type="module" src="http://localhost:5173/@vite/client"></script><script type="module" src="http://localhost:5173/resources/js/app.js"></script>`)
-	actual := s.vite.Assets()
+	actual := vite.Assets()
 
 	assert.Equal(s.T(), expected, actual)
-	s.mockConfig.AssertExpectations(s.T())
 }
 
 func (s *ViteTestSuite) TestAssets_LocalEnvironment_ReactFramework() {
 
-	s.mockConfig.ExpectedCalls = nil
-	s.mockConfig.On("GetString", "app.env", "production").Return("local").Once()
-	s.mockConfig.On("GetString", "vite.js_framework", "vue").Return("react").Once()
-	s.mockConfig.On("GetString", "vite.dev_server_url", "http://localhost:5173").Return("http://localhost:5173").Once()
-	s.mockConfig.On("GetString", "vite.entry_points", "").Return("resources/js/app.jsx").Once()
+	s.config.JSFramework = "react"
+	s.config.EntryPoints = []string{"resources/js/app.jsx"}
+	vite := NewViteWithConfig(s.config)
+	s.writeHotFile("http://localhost:5173")
 
-	actual := s.vite.Assets()
+	actual := vite.Assets()
 	htmlString := string(actual)
 
 	assert.Contains(s.T(), htmlString, `import RefreshRuntime from "http://localhost:5173/@react-refresh";`)
@@ -90,7 +92,6 @@ func (s *ViteTestSuite) TestAssets_LocalEnvironment_ReactFramework() {
 	assert.Contains(s.T(), htmlString, `<script type="module" src="http://localhost:5173/@vite/client"></script>`)
 
 	assert.Contains(s.T(), htmlString, `<script type="module" src="http://localhost:5173/resources/js/app.jsx"></script>`)
-	s.mockConfig.AssertExpectations(s.T())
 }
 
 func (s *ViteTestSuite) TestAssets_Production_SingleEntryPoint_NoCSS() {
@@ -103,24 +104,15 @@ func (s *ViteTestSuite) TestAssets_Production_SingleEntryPoint_NoCSS() {
 		}
 	}`
 
-	s.mockConfig.ExpectedCalls = nil
+	s.config.EntryPoints = []string{entryPoint}
+	vite := NewViteWithConfig(s.config)
 
-	s.mockConfig.On("GetString", "app.env", "production").Return("production").Once()
-
-	s.mockConfig.On("GetString", "vite.js_framework", "vue").Return("vue").Once()
-
-	s.mockConfig.On("GetString", "vite.manifest_path", "public/build/.vite/manifest.json").Return(filepath.Join(s.tempDir, "manifest.json")).Twice()
 	s.writeManifest(manifestContent)
 
-	s.mockConfig.On("GetString", "vite.entry_points", "").Return(entryPoint).Once()
-
-	s.mockConfig.On("GetString", "vite.base_url", "/static/").Return("/static/").Maybe()
-
 	expected := template.HTML(`<link rel="modulepreload" href="/static/assets/app.12345.js"><script type="module" src="/static/assets/app.12345.js"></script>`)
-	actual := s.vite.Assets()
+	actual := vite.Assets()
 
 	assert.Equal(s.T(), expected, actual)
-	s.mockConfig.AssertExpectations(s.T())
 }
 
 func (s *ViteTestSuite) TestAssets_Production_SingleEntryPoint_WithCSS() {
@@ -134,23 +126,17 @@ func (s *ViteTestSuite) TestAssets_Production_SingleEntryPoint_WithCSS() {
 		}
 	}`
 
-	s.mockConfig.ExpectedCalls = nil
-	s.mockConfig.On("GetString", "app.env", "production").Return("production").Once()
-	s.mockConfig.On("GetString", "vite.js_framework", "vue").Return("vue").Once()
-	s.mockConfig.On("GetString", "vite.manifest_path", "public/build/.vite/manifest.json").Return(filepath.Join(s.tempDir, "manifest.json")).Twice()
+	s.config.EntryPoints = []string{entryPoint}
+	vite := NewViteWithConfig(s.config)
 	s.writeManifest(manifestContent)
-	s.mockConfig.On("GetString", "vite.entry_points", "").Return(entryPoint).Once()
-	s.mockConfig.On("GetString", "vite.base_url", "/static/").Return("/static/").Maybe()
 
 	expected := template.HTML(`<link rel="modulepreload" href="/static/assets/app.12345.js"><link rel="preload" href="/static/assets/app.67890.css" as="style"><script type="module" src="/static/assets/app.12345.js"></script><link rel="stylesheet" href="/static/assets/app.67890.css">`)
-	actual := s.vite.Assets()
+	actual := vite.Assets()
 
 	assert.Equal(s.T(), expected, actual)
-	s.mockConfig.AssertExpectations(s.T())
 }
 
 func (s *ViteTestSuite) TestAssets_Production_MultipleEntryPoints() {
-	entryPointsStr := "resources/js/app.js,resources/js/admin.js"
 	manifestContent := `{
 		"resources/js/app.js": {
 			"file": "assets/app.12345.js",
@@ -166,15 +152,11 @@ func (s *ViteTestSuite) TestAssets_Production_MultipleEntryPoints() {
 		}
 	}`
 
-	s.mockConfig.ExpectedCalls = nil
-	s.mockConfig.On("GetString", "app.env", "production").Return("production").Once()
-	s.mockConfig.On("GetString", "vite.js_framework", "vue").Return("vue").Once()
-	s.mockConfig.On("GetString", "vite.manifest_path", "public/build/.vite/manifest.json").Return(filepath.Join(s.tempDir, "manifest.json")).Twice()
+	s.config.EntryPoints = []string{"resources/js/app.js", "resources/js/admin.js"}
+	vite := NewViteWithConfig(s.config)
 	s.writeManifest(manifestContent)
-	s.mockConfig.On("GetString", "vite.entry_points", "").Return(entryPointsStr).Once()
-	s.mockConfig.On("GetString", "vite.base_url", "/static/").Return("/static/").Maybe()
 
-	actual := s.vite.Assets()
+	actual := vite.Assets()
 	htmlString := string(actual)
 
 	assert.Contains(s.T(), htmlString, `<link rel="modulepreload" href="/static/assets/app.12345.js">`)
@@ -186,7 +168,6 @@ func (s *ViteTestSuite) TestAssets_Production_MultipleEntryPoints() {
 	assert.Contains(s.T(), htmlString, `<link rel="stylesheet" href="/static/assets/app.abcde.css">`)
 	assert.Contains(s.T(), htmlString, `<script type="module" src="/static/assets/admin.67890.js"></script>`)
 	assert.Contains(s.T(), htmlString, `<link rel="stylesheet" href="/static/assets/admin.fghij.css">`)
-	s.mockConfig.AssertExpectations(s.T())
 }
 
 func (s *ViteTestSuite) TestAssets_Production_WithImports() {
@@ -203,31 +184,22 @@ func (s *ViteTestSuite) TestAssets_Production_WithImports() {
 		}
 	}`
 
-	s.mockConfig.ExpectedCalls = nil
-	s.mockConfig.On("GetString", "app.env", "production").Return("production").Once()
-	s.mockConfig.On("GetString", "vite.js_framework", "vue").Return("vue").Once()
-	s.mockConfig.On("GetString", "vite.manifest_path", "public/build/.vite/manifest.json").Return(filepath.Join(s.tempDir, "manifest.json")).Twice()
+	s.config.EntryPoints = []string{entryPoint}
+	vite := NewViteWithConfig(s.config)
 	s.writeManifest(manifestContent)
-	s.mockConfig.On("GetString", "vite.entry_points", "").Return(entryPoint).Once()
-	s.mockConfig.On("GetString", "vite.base_url", "/static/").Return("/static/").Maybe()
 
 	expected := template.HTML(`<link rel="modulepreload" href="/static/assets/app.12345.js"><link rel="modulepreload" href="/static/assets/vendor.abcdef.js"><script type="module" src="/static/assets/app.12345.js"></script>`)
-	actual := s.vite.Assets()
+	actual := vite.Assets()
 
 	assert.Equal(s.T(), expected, actual)
-	s.mockConfig.AssertExpectations(s.T())
 }
 
 func (s *ViteTestSuite) TestAssets_Production_ManifestNotFound() {
 
-	s.mockConfig.ExpectedCalls = nil
-	s.mockConfig.On("GetString", "app.env", "production").Return("production").Once()
-	s.mockConfig.On("GetString", "vite.js_framework", "vue").Return("vue").Once()
-	s.mockConfig.On("GetString", "vite.entry_points", "").Return("resources/js/app.js").Once()
-
-	s.mockConfig.On("GetString", "vite.manifest_path", "public/build/.vite/manifest.json").Return(filepath.Join(s.tempDir, "manifest.json")).Once()
+	s.config.EntryPoints = []string{"resources/js/app.js"}
+	vite := NewViteWithConfig(s.config)
 
-	actual := s.vite.Assets()
+	actual := vite.Assets()
 	htmlString := string(actual)
 
 	assert.Contains(s.T(), htmlString, "<!-- ERROR: Could not load Vite manifest:")
@@ -237,19 +209,15 @@ func (s *ViteTestSuite) TestAssets_Production_ManifestNotFound() {
 func (s *ViteTestSuite) TestAssets_Production_InvalidManifest() {
 	manifestContent := `{"invalid json`
 
-	s.mockConfig.ExpectedCalls = nil
-	s.mockConfig.On("GetString", "app.env", "production").Return("production").Once()
-	s.mockConfig.On("GetString", "vite.entry_points", "").Return("resources/js/app.js").Once()
-	s.mockConfig.On("GetString", "vite.js_framework", "vue").Return("vue").Once()
-	s.mockConfig.On("GetString", "vite.manifest_path", "public/build/.vite/manifest.json").Return(filepath.Join(s.tempDir, "manifest.json")).Twice()
+	s.config.EntryPoints = []string{"resources/js/app.js"}
+	vite := NewViteWithConfig(s.config)
 	s.writeManifest(manifestContent)
 
-	actual := s.vite.Assets()
+	actual := vite.Assets()
 	htmlString := string(actual)
 
 	assert.Contains(s.T(), htmlString, "<!-- ERROR: Could not load Vite manifest:")
 	assert.Contains(s.T(), htmlString, "parsing manifest JSON")
-	s.mockConfig.AssertExpectations(s.T())
 }
 
 func (s *ViteTestSuite) TestAssets_Production_EntryPointNotInManifest() {
@@ -262,19 +230,14 @@ func (s *ViteTestSuite) TestAssets_Production_EntryPointNotInManifest() {
 		}
 	}`
 
-	s.mockConfig.ExpectedCalls = nil
-	s.mockConfig.On("GetString", "app.env", "production").Return("production").Once()
-	s.mockConfig.On("GetString", "vite.js_framework", "vue").Return("vue").Once()
-	s.mockConfig.On("GetString", "vite.manifest_path", "public/build/.vite/manifest.json").Return(filepath.Join(s.tempDir, "manifest.json")).Twice()
+	s.config.EntryPoints = []string{missingEntryPoint}
+	vite := NewViteWithConfig(s.config)
 	s.writeManifest(manifestContent)
-	s.mockConfig.On("GetString", "vite.entry_points", "").Return(missingEntryPoint).Once()
-	s.mockConfig.On("GetString", "vite.base_url", "/static/").Return("/static/").Maybe()
 
 	expected := template.HTML(``)
-	actual := s.vite.Assets()
+	actual := vite.Assets()
 
 	assert.Equal(s.T(), expected, actual, "Should render empty string if entry point is missing")
-	s.mockConfig.AssertExpectations(s.T())
 }
 
 func (s *ViteTestSuite) TestAssets_BaseURL_TrailingSlash() {
@@ -284,18 +247,14 @@ func (s *ViteTestSuite) TestAssets_BaseURL_TrailingSlash() {
 		"resources/js/app.js": { "file": "assets/app.12345.js", "src": "resources/js/app.js", "isEntry": true }
 	}`
 
-	s.mockConfig.ExpectedCalls = nil
-	s.mockConfig.On("GetString", "app.env", "production").Return("production").Once()
-	s.mockConfig.On("GetString", "vite.js_framework", "vue").Return("vue").Once()
-	s.mockConfig.On("GetString", "vite.manifest_path", "public/build/.vite/manifest.json").Return(filepath.Join(s.tempDir, "manifest.json")).Twice()
+	s.config.EntryPoints = []string{entryPoint}
+	s.config.BaseURL = baseURL
+	vite := NewViteWithConfig(s.config)
 	s.writeManifest(manifestContent)
-	s.mockConfig.On("GetString", "vite.entry_points", "").Return(entryPoint).Once()
-	s.mockConfig.On("GetString", "vite.base_url", "/static/").Return(baseURL).Maybe()
 
 	expected := template.HTML(`<link rel="modulepreload" href="/custom/static/assets/app.12345.js"><script type="module" src="/custom/static/assets/app.12345.js"></script>`)
-	actual := s.vite.Assets()
+	actual := vite.Assets()
 	assert.Equal(s.T(), expected, actual)
-	s.mockConfig.AssertExpectations(s.T())
 }
 
 func (s *ViteTestSuite) TestAssets_BaseURL_NoTrailingSlash() {
@@ -305,16 +264,366 @@ func (s *ViteTestSuite) TestAssets_BaseURL_NoTrailingSlash() {
 		"resources/js/app.js": { "file": "assets/app.12345.js", "src": "resources/js/app.js", "isEntry": true }
 	}`
 
-	s.mockConfig.ExpectedCalls = nil
-	s.mockConfig.On("GetString", "app.env", "production").Return("production").Once()
-	s.mockConfig.On("GetString", "vite.js_framework", "vue").Return("vue").Once()
-	s.mockConfig.On("GetString", "vite.manifest_path", "public/build/.vite/manifest.json").Return(filepath.Join(s.tempDir, "manifest.json")).Twice()
+	s.config.EntryPoints = []string{entryPoint}
+	s.config.BaseURL = baseURL
+	vite := NewViteWithConfig(s.config)
 	s.writeManifest(manifestContent)
-	s.mockConfig.On("GetString", "vite.entry_points", "").Return(entryPoint).Once()
-	s.mockConfig.On("GetString", "vite.base_url", "/static/").Return(baseURL).Maybe()
 
 	expected := template.HTML(`<link rel="modulepreload" href="/custom/static/assets/app.12345.js"><script type="module" src="/custom/static/assets/app.12345.js"></script>`)
-	actual := s.vite.Assets()
+	actual := vite.Assets()
+	assert.Equal(s.T(), expected, actual)
+}
+
+func (s *ViteTestSuite) TestAssets_LocalEnvironment_SelectedEntries() {
+
+	vite := NewViteWithConfig(s.config)
+	s.writeHotFile("http://localhost:5173")
+
+	expected := template.HTML(`<script type="module" src="http://localhost:5173/@vite/client"></script><script type="module" src="http://localhost:5173/resources/js/admin.ts"></script>`)
+	actual := vite.Assets("resources/js/admin.ts")
+
+	assert.Equal(s.T(), expected, actual)
+}
+
+func (s *ViteTestSuite) TestAssets_Production_SelectedEntries() {
+	manifestContent := `{
+		"resources/js/app.js": {
+			"file": "assets/app.12345.js",
+			"src": "resources/js/app.js",
+			"isEntry": true,
+			"imports": ["_vendor.abcdef.js"],
+			"css": ["assets/shared.abcde.css"]
+		},
+		"resources/js/admin.js": {
+			"file": "assets/admin.67890.js",
+			"src": "resources/js/admin.js",
+			"isEntry": true,
+			"imports": ["_vendor.abcdef.js"],
+			"css": ["assets/shared.abcde.css", "assets/admin.fghij.css"]
+		},
+		"_vendor.abcdef.js": {
+			"file": "assets/vendor.abcdef.js"
+		}
+	}`
+
+	vite := NewViteWithConfig(s.config)
+	s.writeManifest(manifestContent)
+
+	expected := template.HTML(`<link rel="modulepreload" href="/static/assets/admin.67890.js"><link rel="modulepreload" href="/static/assets/vendor.abcdef.js"><link rel="preload" href="/static/assets/shared.abcde.css" as="style"><link rel="preload" href="/static/assets/admin.fghij.css" as="style"><script type="module" src="/static/assets/admin.67890.js"></script><link rel="stylesheet" href="/static/assets/shared.abcde.css"><link rel="stylesheet" href="/static/assets/admin.fghij.css">`)
+	actual := vite.Assets("resources/js/admin.js")
+	assert.Equal(s.T(), expected, actual)
+
+	actual = vite.Assets("resources/js/app.js", "resources/js/admin.js")
+	htmlString := string(actual)
+	assert.Equal(s.T(), 1, strings.Count(htmlString, `<link rel="modulepreload" href="/static/assets/vendor.abcdef.js">`))
+	assert.Equal(s.T(), 1, strings.Count(htmlString, `<link rel="stylesheet" href="/static/assets/shared.abcde.css">`))
+	assert.Contains(s.T(), htmlString, `<script type="module" src="/static/assets/app.12345.js"></script>`)
+	assert.Contains(s.T(), htmlString, `<script type="module" src="/static/assets/admin.67890.js"></script>`)
+}
+
+func (s *ViteTestSuite) TestAssets_HotFile_FallsBackToDevServerURL() {
+
+	s.config.DevServerURL = "http://127.0.0.1:3000"
+	s.config.EntryPoints = []string{"resources/js/app.js"}
+	vite := NewViteWithConfig(s.config)
+	s.writeHotFile("\n")
+
+	expected := template.HTML(`<script type="module" src="http://127.0.0.1:3000/@vite/client"></script><script type="module" src="http://127.0.0.1:3000/resources/js/app.js"></script>`)
+	actual := vite.Assets()
+
 	assert.Equal(s.T(), expected, actual)
-	s.mockConfig.AssertExpectations(s.T())
+}
+
+func (s *ViteTestSuite) TestAssets_HotFile_UsesURLFromFile() {
+
+	vite := NewViteWithConfig(s.config)
+	s.writeHotFile("http://[::1]:5174/\n")
+
+	expected := template.HTML(`<script type="module" src="http://[::1]:5174/@vite/client"></script><script type="module" src="http://[::1]:5174/resources/js/app.js"></script>`)
+	actual := vite.Assets("resources/js/app.js")
+
+	assert.Equal(s.T(), expected, actual)
+}
+
+func (s *ViteTestSuite) TestAssets_Production_IntegrityFromManifest() {
+	manifestContent := `{
+		"resources/js/app.js": {
+			"file": "assets/app.12345.js",
+			"src": "resources/js/app.js",
+			"isEntry": true,
+			"integrity": "sha384-app"
+		}
+	}`
+
+	s.config.Integrity = "manifest"
+	vite := NewViteWithConfig(s.config)
+	s.writeManifest(manifestContent)
+
+	expected := template.HTML(`<link rel="modulepreload" href="/static/assets/app.12345.js" integrity="sha384-app" crossorigin="anonymous"><script type="module" src="/static/assets/app.12345.js" integrity="sha384-app" crossorigin="anonymous"></script>`)
+	actual := vite.Assets("resources/js/app.js")
+
+	assert.Equal(s.T(), expected, actual)
+}
+
+func (s *ViteTestSuite) TestAssets_Production_IntegrityComputed() {
+	manifestContent := `{
+		"resources/js/app.js": {
+			"file": "assets/app.12345.js",
+			"src": "resources/js/app.js",
+			"isEntry": true,
+			"css": ["assets/app.67890.css"]
+		}
+	}`
+	s.Require().NoError(os.MkdirAll(filepath.Join(s.tempDir, "assets"), 0755))
+	s.Require().NoError(os.WriteFile(filepath.Join(s.tempDir, "assets", "app.12345.js"), []byte("console.log('app')"), 0644))
+	s.Require().NoError(os.WriteFile(filepath.Join(s.tempDir, "assets", "app.67890.css"), []byte("body{}"), 0644))
+
+	s.config.Integrity = "sha384"
+	vite := NewViteWithConfig(s.config)
+	s.writeManifest(manifestContent)
+
+	jsIntegrity := `integrity="sha384-KYuqKizs0iBUTSkGWubfX5wANSj0T0CBYI4AJEWyhsi0RKC7h8+mxTJqgFccGDeg" crossorigin="anonymous"`
+	cssIntegrity := `integrity="sha384-myyg/hQ74aSgjBBvVME/QXAXEkT4Y9dHbVQ5C0lIyGpldvNLJV2IWc5ElXbqLi06" crossorigin="anonymous"`
+
+	actual := string(vite.Assets("resources/js/app.js"))
+
+	assert.Contains(s.T(), actual, `<script type="module" src="/static/assets/app.12345.js" `+jsIntegrity+`></script>`)
+	assert.Contains(s.T(), actual, `<link rel="modulepreload" href="/static/assets/app.12345.js" `+jsIntegrity+`>`)
+	assert.Contains(s.T(), actual, `<link rel="preload" href="/static/assets/app.67890.css" as="style" `+cssIntegrity+`>`)
+	assert.Contains(s.T(), actual, `<link rel="stylesheet" href="/static/assets/app.67890.css" `+cssIntegrity+`>`)
+}
+
+func (s *ViteTestSuite) TestAssets_Production_IntegrityMissingFile() {
+	manifestContent := `{
+		"resources/js/app.js": {
+			"file": "assets/app.12345.js",
+			"src": "resources/js/app.js",
+			"isEntry": true,
+			"css": ["assets/app.67890.css"]
+		},
+		"resources/images/logo.png": { "file": "assets/logo.12345.png", "src": "resources/images/logo.png" }
+	}`
+	s.Require().NoError(os.MkdirAll(filepath.Join(s.tempDir, "assets"), 0755))
+	s.Require().NoError(os.WriteFile(filepath.Join(s.tempDir, "assets", "app.67890.css"), []byte("body{}"), 0644))
+
+	mockLog := mockslog.NewLog(s.T())
+	mockLog.EXPECT().Warning(mock.MatchedBy(func(message string) bool {
+		return strings.HasPrefix(message, `vite: rendering "assets/app.12345.js" without integrity:`)
+	})).Once()
+	s.config.Integrity = "sha384"
+	s.config.Logger = mockLog
+	vite := NewViteWithConfig(s.config)
+	s.writeManifest(manifestContent)
+
+	actual := string(vite.Assets("resources/js/app.js"))
+
+	assert.Contains(s.T(), actual, `<script type="module" src="/static/assets/app.12345.js"></script>`)
+	assert.Contains(s.T(), actual, `<link rel="stylesheet" href="/static/assets/app.67890.css" integrity="sha384-myyg/hQ74aSgjBBvVME/QXAXEkT4Y9dHbVQ5C0lIyGpldvNLJV2IWc5ElXbqLi06" crossorigin="anonymous">`)
+}
+
+func (s *ViteTestSuite) TestAssets_Production_IntegrityEscaped() {
+	manifestContent := `{
+		"resources/js/app.js": {
+			"file": "assets/app.12345.js",
+			"src": "resources/js/app.js",
+			"isEntry": true,
+			"integrity": "sha384-app\"><script>alert(1)</script>"
+		}
+	}`
+
+	s.config.Integrity = "manifest"
+	vite := NewViteWithConfig(s.config)
+	s.writeManifest(manifestContent)
+
+	actual := string(vite.Assets("resources/js/app.js"))
+
+	assert.NotContains(s.T(), actual, "<script>alert(1)</script>")
+	assert.Contains(s.T(), actual, `integrity="sha384-app&#34;&gt;&lt;script&gt;alert(1)&lt;/script&gt;"`)
+}
+
+func (s *ViteTestSuite) TestAssets_Production_IntegrityUnsupportedAlgorithm() {
+	manifestContent := `{
+		"resources/js/app.js": { "file": "assets/app.12345.js", "src": "resources/js/app.js", "isEntry": true }
+	}`
+
+	s.config.Integrity = "md5"
+	vite := NewViteWithConfig(s.config)
+	s.writeManifest(manifestContent)
+
+	actual := string(vite.Assets("resources/js/app.js"))
+
+	assert.Contains(s.T(), actual, "<!-- ERROR: Could not load Vite manifest:")
+	assert.Contains(s.T(), actual, `unsupported integrity algorithm "md5"`)
+}
+
+func (s *ViteTestSuite) TestAssetsWithNonce_LocalEnvironment_ReactFramework() {
+
+	mockCtx := mockshttp.NewContext(s.T())
+	mockCtx.EXPECT().Value(nonceContextKey{}).Return("r4nd0m").Once()
+
+	s.config.JSFramework = "react"
+	vite := NewViteWithConfig(s.config)
+	s.writeHotFile("http://localhost:5173")
+
+	htmlString := string(vite.AssetsWithNonce(mockCtx, "resources/js/app.tsx"))
+
+	assert.Contains(s.T(), htmlString, `<script type="module" nonce="r4nd0m">`)
+	assert.Contains(s.T(), htmlString, `<script type="module" src="http://localhost:5173/@vite/client" nonce="r4nd0m"></script>`)
+	assert.Contains(s.T(), htmlString, `<script type="module" src="http://localhost:5173/resources/js/app.tsx" nonce="r4nd0m"></script>`)
+}
+
+func (s *ViteTestSuite) TestAssetsWithNonce_Production() {
+	manifestContent := `{
+		"resources/js/app.js": {
+			"file": "assets/app.12345.js",
+			"src": "resources/js/app.js",
+			"isEntry": true,
+			"css": ["assets/app.67890.css"]
+		}
+	}`
+
+	mockCtx := mockshttp.NewContext(s.T())
+	mockCtx.EXPECT().Value(nonceContextKey{}).Return("r4nd0m").Once()
+
+	vite := NewViteWithConfig(s.config)
+	s.writeManifest(manifestContent)
+
+	expected := template.HTML(`<link rel="modulepreload" href="/static/assets/app.12345.js" nonce="r4nd0m"><link rel="preload" href="/static/assets/app.67890.css" as="style" nonce="r4nd0m"><script type="module" src="/static/assets/app.12345.js" nonce="r4nd0m"></script><link rel="stylesheet" href="/static/assets/app.67890.css" nonce="r4nd0m">`)
+	actual := vite.AssetsWithNonce(mockCtx, "resources/js/app.js")
+
+	assert.Equal(s.T(), expected, actual)
+}
+
+func (s *ViteTestSuite) TestAsset_LocalEnvironment() {
+
+	vite := NewViteWithConfig(s.config)
+	s.writeHotFile("http://localhost:5173")
+
+	url, err := vite.Asset("resources/images/logo.svg")
+
+	s.Require().NoError(err)
+	assert.Equal(s.T(), "http://localhost:5173/resources/images/logo.svg", url)
+}
+
+func (s *ViteTestSuite) TestAsset_Production() {
+	manifestContent := `{
+		"resources/images/logo.svg": {
+			"file": "assets/logo.12345.svg",
+			"src": "resources/images/logo.svg"
+		}
+	}`
+
+	s.config.BaseURL = "/static"
+	vite := NewViteWithConfig(s.config)
+	s.writeManifest(manifestContent)
+
+	url, err := vite.Asset("resources/images/logo.svg")
+	s.Require().NoError(err)
+	assert.Equal(s.T(), "/static/assets/logo.12345.svg", url)
+
+	_, err = vite.Asset("resources/images/missing.svg")
+	assert.EqualError(s.T(), err, "unable to locate file in Vite manifest: resources/images/missing.svg")
+}
+
+func (s *ViteTestSuite) TestAssets_Production_AssetURL() {
+	manifestContent := `{
+		"resources/js/app.js": {
+			"file": "assets/app.12345.js",
+			"src": "resources/js/app.js",
+			"isEntry": true,
+			"css": ["assets/app.67890.css"],
+			"integrity": "sha384-app"
+		}
+	}`
+
+	s.config.Integrity = "manifest"
+	s.config.AssetURL = "https://cdn.example.com/build/"
+	s.config.CrossOrigin = "use-credentials"
+	vite := NewViteWithConfig(s.config)
+	s.writeManifest(manifestContent)
+
+	expected := template.HTML(`<link rel="modulepreload" href="https://cdn.example.com/build/assets/app.12345.js" integrity="sha384-app" crossorigin="use-credentials">` +
+		`<link rel="preload" href="https://cdn.example.com/build/assets/app.67890.css" as="style" crossorigin="use-credentials">` +
+		`<script type="module" src="https://cdn.example.com/build/assets/app.12345.js" integrity="sha384-app" crossorigin="use-credentials"></script>` +
+		`<link rel="stylesheet" href="https://cdn.example.com/build/assets/app.67890.css" crossorigin="use-credentials">`)
+	actual := vite.Assets("resources/js/app.js")
+
+	assert.Equal(s.T(), expected, actual)
+}
+
+func (s *ViteTestSuite) TestAsset_Production_AssetURL() {
+	manifestContent := `{
+		"resources/images/logo.svg": {
+			"file": "assets/logo.12345.svg",
+			"src": "resources/images/logo.svg"
+		}
+	}`
+
+	s.config.AssetURL = "https://cdn.example.com"
+	vite := NewViteWithConfig(s.config)
+	s.writeManifest(manifestContent)
+
+	url, err := vite.Asset("resources/images/logo.svg")
+
+	s.Require().NoError(err)
+	assert.Equal(s.T(), "https://cdn.example.com/assets/logo.12345.svg", url)
+}
+
+func (s *ViteTestSuite) TestReload() {
+	manifestPath := filepath.Join(s.tempDir, "manifest.json")
+
+	vite := NewViteWithConfig(s.config)
+
+	s.Require().NoError(os.WriteFile(manifestPath, []byte(`{"resources/js/app.js": {"file": "assets/app.11111.js", "isEntry": true}}`), 0644))
+	assert.Contains(s.T(), string(vite.Assets("resources/js/app.js")), "assets/app.11111.js")
+
+	s.Require().NoError(os.WriteFile(manifestPath, []byte(`{"resources/js/app.js": {"file": "assets/app.22222.js", "isEntry": true}}`), 0644))
+	assert.Contains(s.T(), string(vite.Assets("resources/js/app.js")), "assets/app.11111.js", "Manifest should stay cached until reloaded")
+
+	s.Require().NoError(vite.Reload())
+	assert.Contains(s.T(), string(vite.Assets("resources/js/app.js")), "assets/app.22222.js")
+
+	s.Require().NoError(os.Remove(manifestPath))
+	s.Require().Error(vite.Reload())
+	assert.Contains(s.T(), string(vite.Assets("resources/js/app.js")), "assets/app.22222.js", "Failed reload should keep the previous manifest")
+}
+
+func (s *ViteTestSuite) TestFlush() {
+	manifestPath := filepath.Join(s.tempDir, "manifest.json")
+
+	s.config.EntryPoints = []string{"resources/js/app.js"}
+	vite := NewViteWithConfig(s.config)
+
+	s.Require().NoError(os.WriteFile(manifestPath, []byte(`{"resources/js/app.js": {"file": "assets/app.11111.js", "isEntry": true}}`), 0644))
+	assert.Contains(s.T(), string(vite.Assets()), "assets/app.11111.js")
+
+	s.Require().NoError(os.WriteFile(manifestPath, []byte(`{"resources/js/app.js": {"file": "assets/app.22222.js", "isEntry": true}}`), 0644))
+	vite.Flush()
+	assert.Contains(s.T(), string(vite.Assets()), "assets/app.22222.js")
+}
+
+func (s *ViteTestSuite) TestAssets_InstancesDoNotShareManifest() {
+	otherDir := s.T().TempDir()
+	otherConfig := testConfig(otherDir)
+	otherConfig.BaseURL = "/admin/"
+	other := NewViteWithConfig(otherConfig)
+	vite := NewViteWithConfig(s.config)
+
+	s.writeManifest(`{"resources/js/app.js": {"file": "assets/site.js", "isEntry": true}}`)
+	s.Require().NoError(os.WriteFile(otherConfig.ManifestPath, []byte(`{"resources/js/app.js": {"file": "assets/admin.js", "isEntry": true}}`), 0644))
+
+	assert.Contains(s.T(), string(vite.Assets("resources/js/app.js")), "/static/assets/site.js")
+	assert.Contains(s.T(), string(other.Assets("resources/js/app.js")), "/admin/assets/admin.js")
+}
+
+func (s *ViteTestSuite) TestManifestHash() {
+	manifestContent := `{"resources/js/app.js": {"file": "assets/app.12345.js", "isEntry": true}}`
+
+	vite := NewViteWithConfig(s.config)
+	s.writeManifest(manifestContent)
+
+	hash, err := vite.ManifestHash()
+
+	s.Require().NoError(err)
+	assert.Equal(s.T(), "1f214bfd8ed91e931b10364d3b88a35e", hash)
 }
@@ -0,0 +1,83 @@
+package vite
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/goravel/framework/support/path"
+)
+
+type manifestStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// WatchManifest polls the manifest file and reloads it once it differs from
+// the loaded one and has been stable for the debounce period, calling
+// onReload with the outcome. The file is polled rather than watched because
+// vite build recreates the .vite directory, which silently drops inotify
+// watches. It blocks until ctx is done. Nothing is watched when vite.fs is
+// set, since the manifest is then read from a file system that cannot change.
+func (v *Vite) WatchManifest(ctx context.Context, interval, debounce time.Duration, onReload func(error)) {
+	if v.config.FS != nil {
+		<-ctx.Done()
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var pending, failed manifestStamp
+	var changedAt time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		stamp, ok := v.manifestStamp()
+		if !ok || stamp == v.loadedStamp() || stamp == failed {
+			continue
+		}
+
+		if stamp != pending {
+			pending = stamp
+			changedAt = time.Now()
+		}
+
+		if time.Since(changedAt) < debounce {
+			continue
+		}
+
+		err := v.Reload()
+		if err != nil {
+			failed = stamp
+		}
+		if onReload != nil {
+			onReload(err)
+		}
+	}
+}
+
+func (v *Vite) loadedStamp() manifestStamp {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	if v.build == nil {
+		return manifestStamp{}
+	}
+
+	return v.build.stamp
+}
+
+func (v *Vite) manifestStamp() (manifestStamp, bool) {
+	info, err := os.Stat(path.Base(v.config.ManifestPath))
+	if err != nil {
+		return manifestStamp{}, false
+	}
+
+	return manifestStamp{modTime: info.ModTime(), size: info.Size()}, true
+}
@@ -0,0 +1,83 @@
+package vite
+
+import (
+	"context"
+	"os"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchManifest(t *testing.T) {
+	config := testConfig(t.TempDir())
+	manifestPath := config.ManifestPath
+	require.NoError(t, os.WriteFile(manifestPath, []byte(`{"resources/js/app.js": {"file": "assets/app.1.js", "isEntry": true}}`), 0644))
+
+	vite := NewViteWithConfig(config)
+	url, err := vite.Asset("resources/js/app.js")
+	require.NoError(t, err)
+	assert.Equal(t, "/static/assets/app.1.js", url)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reloaded := make(chan error, 1)
+	go vite.WatchManifest(ctx, 5*time.Millisecond, 20*time.Millisecond, func(err error) {
+		reloaded <- err
+	})
+
+	require.NoError(t, os.WriteFile(manifestPath, []byte(`{"resources/js/app.js": {"file": "assets/app.22.js", "isEntry": true}}`), 0644))
+
+	select {
+	case err := <-reloaded:
+		require.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("manifest was not reloaded")
+	}
+
+	url, err = vite.Asset("resources/js/app.js")
+	require.NoError(t, err)
+	assert.Equal(t, "/static/assets/app.22.js", url)
+}
+
+func TestWatchManifest_StopsWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		NewViteWithConfig(testConfig(t.TempDir())).WatchManifest(ctx, time.Millisecond, time.Millisecond, nil)
+		close(done)
+	}()
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("watcher did not stop")
+	}
+}
+
+func TestWatchManifest_FS(t *testing.T) {
+	config := testConfig(t.TempDir())
+	config.FS = fstest.MapFS{
+		"manifest.json": {Data: []byte(`{"resources/js/app.js": {"file": "assets/app.1.js", "isEntry": true}}`)},
+	}
+	require.NoError(t, os.WriteFile(config.ManifestPath, []byte(`{"resources/js/app.js": {"file": "assets/app.22.js", "isEntry": true}}`), 0644))
+
+	vite := NewViteWithConfig(config)
+	_, err := vite.loadManifest()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var reloads int
+	vite.WatchManifest(ctx, time.Millisecond, 0, func(error) {
+		reloads++
+	})
+
+	assert.Zero(t, reloads)
+}